@@ -0,0 +1,108 @@
+package benchmark
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/joomcode/errorx"
+)
+
+var (
+	propertySink interface{}
+	properties   = registerProperties(64)
+)
+
+func registerProperties(count int) []errorx.Property {
+	result := make([]errorx.Property, count)
+	for i := range result {
+		result[i] = errorx.RegisterProperty("benchmark_property_" + strconv.Itoa(i))
+	}
+	return result
+}
+
+func createErrorWithProperties(count int) *errorx.Error {
+	err := errorx.IllegalState.NewWithNoMessage()
+	for i := 0; i < count; i++ {
+		err = err.WithProperty(properties[i], i)
+	}
+	return err
+}
+
+func benchmarkWithProperty(b *testing.B, count int) {
+	for n := 0; n < b.N; n++ {
+		errorSink = createErrorWithProperties(count)
+	}
+	consumeResult(errorSink)
+}
+
+func benchmarkPropertyLookup(b *testing.B, count int) {
+	err := createErrorWithProperties(count)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < count; i++ {
+			propertySink, _ = err.Property(properties[i])
+		}
+	}
+}
+
+func benchmarkDecoratedPropertyLookup(b *testing.B, count int) {
+	err := createErrorWithProperties(count)
+	for i := 0; i < 3; i++ {
+		err = errorx.Decorate(err, "decorated")
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < count; i++ {
+			propertySink, _ = err.Property(properties[i])
+		}
+	}
+}
+
+// benchmarkLayeredPropertyLookup spreads the properties over several decorations
+func benchmarkLayeredPropertyLookup(b *testing.B, count int) {
+	err := errorx.IllegalState.NewWithNoMessage()
+	for i := 0; i < count; i++ {
+		if i%5 == 4 {
+			err = errorx.Decorate(err, "decorated")
+		}
+		err = err.WithProperty(properties[i], i)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < count; i++ {
+			propertySink, _ = err.Property(properties[i])
+		}
+	}
+}
+
+// benchmarkWithPropertyAndLookup looks up each property once, so that an error is indexed for a single use
+func benchmarkWithPropertyAndLookup(b *testing.B, count int) {
+	for n := 0; n < b.N; n++ {
+		err := createErrorWithProperties(count)
+		for i := 0; i < count; i++ {
+			propertySink, _ = err.Property(properties[i])
+		}
+	}
+}
+
+func BenchmarkWithProperty1(b *testing.B)  { benchmarkWithProperty(b, 1) }
+func BenchmarkWithProperty3(b *testing.B)  { benchmarkWithProperty(b, 3) }
+func BenchmarkWithProperty20(b *testing.B) { benchmarkWithProperty(b, 20) }
+func BenchmarkWithProperty64(b *testing.B) { benchmarkWithProperty(b, 64) }
+
+func BenchmarkPropertyLookup1(b *testing.B)  { benchmarkPropertyLookup(b, 1) }
+func BenchmarkPropertyLookup3(b *testing.B)  { benchmarkPropertyLookup(b, 3) }
+func BenchmarkPropertyLookup8(b *testing.B)  { benchmarkPropertyLookup(b, 8) }
+func BenchmarkPropertyLookup12(b *testing.B) { benchmarkPropertyLookup(b, 12) }
+func BenchmarkPropertyLookup16(b *testing.B) { benchmarkPropertyLookup(b, 16) }
+func BenchmarkPropertyLookup20(b *testing.B) { benchmarkPropertyLookup(b, 20) }
+func BenchmarkPropertyLookup32(b *testing.B) { benchmarkPropertyLookup(b, 32) }
+func BenchmarkPropertyLookup64(b *testing.B) { benchmarkPropertyLookup(b, 64) }
+
+func BenchmarkDecoratedPropertyLookup3(b *testing.B)  { benchmarkDecoratedPropertyLookup(b, 3) }
+func BenchmarkDecoratedPropertyLookup20(b *testing.B) { benchmarkDecoratedPropertyLookup(b, 20) }
+
+func BenchmarkLayeredPropertyLookup20(b *testing.B) { benchmarkLayeredPropertyLookup(b, 20) }
+
+func BenchmarkWithPropertyAndLookup3(b *testing.B)  { benchmarkWithPropertyAndLookup(b, 3) }
+func BenchmarkWithPropertyAndLookup20(b *testing.B) { benchmarkWithPropertyAndLookup(b, 20) }
@@ -1,6 +1,7 @@
 package errorx
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 )
@@ -9,11 +10,16 @@ import (
 // Typically, a direct usage is not required: either Type methods of helpers like Decorate are sufficient.
 // Only use builder if no simpler alternative is available.
 type ErrorBuilder struct {
-	errorType     *Type
-	message       string
-	cause         error
-	mode          callStackBuildMode
-	isTransparent bool
+	errorType      *Type
+	message        string
+	cause          error
+	mode           callStackBuildMode
+	isTransparent  bool
+	publicMessage  string
+	template       *MessageTemplate
+	templateValues []interface{}
+	ctx            context.Context
+	stackTrace     *stackTrace
 }
 
 // NewErrorBuilder creates error builder from an existing error type.
@@ -81,6 +87,14 @@ func (eb ErrorBuilder) EnhanceStackTrace() ErrorBuilder {
 	return eb
 }
 
+// enhanceStackTraceFrom is the same as EnhanceStackTrace, except that a stack trace collected earlier is used instead of the current one,
+// such as that of a site a goroutine was spawned from
+func (eb ErrorBuilder) enhanceStackTraceFrom(st *stackTrace) ErrorBuilder {
+	eb = eb.EnhanceStackTrace()
+	eb.stackTrace = st
+	return eb
+}
+
 // WithConditionallyFormattedMessage provides a message for an error in flexible format, to simplify its usages.
 // Without args, leaves the original message intact, so a message may be generated or provided externally.
 // With args, a formatting is performed, and it is therefore expected a format string to be constant.
@@ -103,6 +117,18 @@ func (eb ErrorBuilder) Create() *Error {
 		transparent: eb.isTransparent,
 		stackTrace:  eb.assembleStackTrace(),
 	}
+	if cause := Cast(eb.cause); cause != nil && eb.isTransparent {
+		err.inheritedPropertyCount = uint32(cause.visiblePropertyCount())
+	}
+	if eb.publicMessage != "" {
+		err.properties = err.properties.with(propertyPublicMessage, eb.publicMessage)
+	}
+	if eb.ctx != nil {
+		err = err.withContextProperties(eb.ctx)
+	}
+	if eb.template != nil {
+		err = err.withTemplate(eb.template, eb.templateValues)
+	}
 	return err
 }
 
@@ -138,6 +164,10 @@ func (eb ErrorBuilder) assembleStackTrace() *stackTrace {
 }
 
 func (eb ErrorBuilder) collectOriginalStackTrace() *stackTrace {
+	if eb.stackTrace != nil {
+		return eb.stackTrace
+	}
+
 	return collectStackTrace()
 }
 
@@ -146,7 +176,10 @@ func (eb ErrorBuilder) borrowStackTraceFromCause() *stackTrace {
 }
 
 func (eb ErrorBuilder) combineStackTraceWithCause() *stackTrace {
-	currentStackTrace := collectStackTrace()
+	currentStackTrace := eb.stackTrace
+	if currentStackTrace == nil {
+		currentStackTrace = collectStackTrace()
+	}
 
 	originalStackTrace := eb.extractStackTraceFromCause(eb.cause)
 	if originalStackTrace != nil {
@@ -0,0 +1,136 @@
+package errorx
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// TraitClassifier checks if a foreign, non-errorx error possesses a trait, see RegisterTraitClassifier.
+type TraitClassifier func(err error) bool
+
+// TypeClassifier maps a foreign, non-errorx error to an error type, or to nil if it has none, see RegisterTypeClassifier.
+type TypeClassifier func(err error) *Type
+
+// RegisterTraitClassifier provides a rule for foreign errors to possess a trait, as far as HasTrait and TraitSwitch are concerned.
+// A foreign error is classified both when checked by itself, and when it is a cause of a transparent errorx wrapper, such as by Decorate.
+// A number of rules for the standard library errors are registered by default, such as Timeout() for an error with a Timeout() method
+// which returns true, or NotFound() for os.ErrNotExist. Rules for other packages, so that errorx does not depend on them, are up to the user:
+//
+//	errorx.RegisterTraitClassifier(errorx.NotFound(), func(err error) bool {
+//		return errors.Is(err, sql.ErrNoRows)
+//	})
+func RegisterTraitClassifier(trait Trait, classifier TraitClassifier) {
+	classifiers.mu.Lock()
+	defer classifiers.mu.Unlock()
+
+	classifiers.traits = append(classifiers.traits, traitClassifier{trait: trait, classify: classifier})
+}
+
+// RegisterTypeClassifier provides a rule for foreign errors to be of an error type, as far as IsOfType, TypeSwitch and Error.Type are concerned.
+// Just as with traits, a foreign error is classified both when checked by itself, and when it is a cause of a transparent errorx wrapper,
+// such as by Decorate. An opaque wrap, such as by Type.Wrap, has a type of its own, so a foreign error is to be classified beforehand, see Classify.
+// Foreign errors also possess the traits of a type they are classified into.
+// Classifiers are consulted from the most recently registered one, so that a user rule takes precedence over a default one.
+// A number of rules for the standard library errors are registered by default, such as TimeoutElapsed for context.DeadlineExceeded.
+func RegisterTypeClassifier(classifier TypeClassifier) {
+	classifiers.mu.Lock()
+	defer classifiers.mu.Unlock()
+
+	classifiers.types = append(classifiers.types, classifier)
+}
+
+// Classify wraps a foreign error into an error type it is classified into, see RegisterTypeClassifier.
+// An errorx error, as well as a foreign error which has no type, is returned as is.
+// This is only needed before an opaque wrap, as type checks classify a foreign error by themselves.
+func Classify(err error) error {
+	if err == nil || Cast(err) != nil {
+		return err
+	}
+
+	t := classifyType(err)
+	if t == nil {
+		return err
+	}
+
+	return NewErrorBuilder(t).
+		WithCause(err).
+		Create()
+}
+
+// classifyTrait checks if a foreign error possesses a trait, either by a trait classifier or by a type it is classified into
+func classifyTrait(err error, trait Trait) bool {
+	traitClassifiers, _ := registeredClassifiers()
+	for _, classifier := range traitClassifiers {
+		if (classifier.trait == trait || classifier.trait.implies(trait)) && classifier.classify(err) {
+			return true
+		}
+	}
+
+	t := classifyType(err)
+	return t != nil && t.HasTrait(trait)
+}
+
+func classifyType(err error) *Type {
+	_, typeClassifiers := registeredClassifiers()
+	for i := len(typeClassifiers) - 1; i >= 0; i-- {
+		if t := typeClassifiers[i](err); t != nil {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// isOfClassifiedType checks if a foreign error is classified into a type, see RegisterTypeClassifier
+func isOfClassifiedType(err error, t *Type) bool {
+	if err == nil {
+		return false
+	}
+
+	classified := classifyType(err)
+	return classified != nil && classified.IsOfType(t)
+}
+
+// registeredClassifiers returns the classifiers registered so far, which are then called without a lock,
+// so that a classifier may check traits or types of other errors itself
+func registeredClassifiers() ([]traitClassifier, []TypeClassifier) {
+	classifiers.mu.RLock()
+	defer classifiers.mu.RUnlock()
+
+	// the slices are only appended to, so it is enough to limit their length
+	return classifiers.traits[:len(classifiers.traits):len(classifiers.traits)], classifiers.types[:len(classifiers.types):len(classifiers.types)]
+}
+
+type traitClassifier struct {
+	trait    Trait
+	classify TraitClassifier
+}
+
+var classifiers = struct {
+	mu     *sync.RWMutex
+	traits []traitClassifier
+	types  []TypeClassifier
+}{
+	mu: &sync.RWMutex{},
+}
+
+func init() {
+	RegisterTraitClassifier(Timeout(), isTimeoutError)
+	RegisterTraitClassifier(Temporary(), isTemporaryError)
+	RegisterTraitClassifier(NotFound(), isNotExistError)
+	RegisterTraitClassifier(Duplicate(), isExistError)
+
+	RegisterTypeClassifier(func(err error) *Type {
+		switch {
+		case isError(err, context.DeadlineExceeded):
+			return TimeoutElapsed
+		case isError(err, context.Canceled):
+			return Interrupted
+		case isError(err, io.ErrUnexpectedEOF):
+			return IllegalFormat
+		default:
+			return nil
+		}
+	})
+}
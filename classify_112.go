@@ -0,0 +1,29 @@
+// +build !go1.13
+
+package errorx
+
+import (
+	"os"
+)
+
+func isError(err, target error) bool {
+	return err == target
+}
+
+func isTimeoutError(err error) bool {
+	timeout, ok := err.(interface{ Timeout() bool })
+	return ok && timeout.Timeout()
+}
+
+func isTemporaryError(err error) bool {
+	temporary, ok := err.(interface{ Temporary() bool })
+	return ok && temporary.Temporary()
+}
+
+func isNotExistError(err error) bool {
+	return os.IsNotExist(err)
+}
+
+func isExistError(err error) bool {
+	return os.IsExist(err)
+}
@@ -0,0 +1,30 @@
+// +build go1.13
+
+package errorx
+
+import (
+	"errors"
+	"os"
+)
+
+func isError(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+func isTimeoutError(err error) bool {
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+func isTemporaryError(err error) bool {
+	var temporary interface{ Temporary() bool }
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
+
+func isNotExistError(err error) bool {
+	return errors.Is(err, os.ErrNotExist)
+}
+
+func isExistError(err error) bool {
+	return errors.Is(err, os.ErrExist)
+}
@@ -0,0 +1,18 @@
+// +build go1.13
+
+package errorx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyWrapped(t *testing.T) {
+	require.True(t, IsNotFound(fmt.Errorf("open: %w", os.ErrNotExist)))
+	require.True(t, IsTimeout(fmt.Errorf("call: %w", classifyTestTimeout{})))
+	require.True(t, IsOfType(Classify(fmt.Errorf("call: %w", context.DeadlineExceeded)), TimeoutElapsed))
+}
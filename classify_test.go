@@ -0,0 +1,158 @@
+package errorx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type classifyTestTimeout struct{}
+
+func (classifyTestTimeout) Error() string   { return "timeout" }
+func (classifyTestTimeout) Timeout() bool   { return true }
+func (classifyTestTimeout) Temporary() bool { return false }
+
+var (
+	classifyTestTrait   = RegisterTrait("classify")
+	classifyTestType    = NewNamespace("classify").NewType("type", classifyTestTrait)
+	classifyTestErr     = errors.New("classified")
+	classifyTestTypeErr = errors.New("classified type")
+)
+
+func init() {
+	RegisterTraitClassifier(classifyTestTrait, func(err error) bool {
+		return err == classifyTestErr
+	})
+	RegisterTypeClassifier(func(err error) *Type {
+		if err == classifyTestTypeErr {
+			return classifyTestType
+		}
+		return nil
+	})
+}
+
+func TestClassifyTraits(t *testing.T) {
+	t.Run("Standard", func(t *testing.T) {
+		require.True(t, IsTimeout(classifyTestTimeout{}))
+		require.False(t, IsTemporary(classifyTestTimeout{}))
+		require.True(t, IsTimeout(context.DeadlineExceeded))
+		require.True(t, IsNotFound(os.ErrNotExist))
+		require.True(t, IsDuplicate(os.ErrExist))
+		require.False(t, IsNotFound(io.EOF))
+		require.False(t, HasTrait(nil, NotFound()))
+
+		_, err := os.Open("/nonexistent/errorx/classify")
+		require.True(t, IsNotFound(err))
+	})
+
+	t.Run("User", func(t *testing.T) {
+		require.True(t, HasTrait(classifyTestErr, classifyTestTrait))
+		require.True(t, HasTrait(classifyTestTypeErr, classifyTestTrait))
+		require.False(t, HasTrait(errors.New("other"), classifyTestTrait))
+	})
+
+	t.Run("Decorated", func(t *testing.T) {
+		require.True(t, IsNotFound(Decorate(os.ErrNotExist, "decorated")))
+		require.True(t, IsTimeout(EnsureStackTrace(classifyTestTimeout{})))
+		require.False(t, IsNotFound(testType.Wrap(os.ErrNotExist, "wrapped")))
+	})
+
+	t.Run("TraitSwitch", func(t *testing.T) {
+		require.Equal(t, NotFound(), TraitSwitch(os.ErrNotExist, Timeout(), NotFound()))
+		require.Equal(t, Timeout(), TraitSwitch(Decorate(context.DeadlineExceeded, "decorated"), Timeout(), NotFound()))
+		require.Equal(t, CaseNoTrait(), TraitSwitch(io.EOF, Timeout(), NotFound()))
+	})
+}
+
+func TestClassifyTypes(t *testing.T) {
+	t.Run("Standard", func(t *testing.T) {
+		require.True(t, IsOfType(Classify(context.DeadlineExceeded), TimeoutElapsed))
+		require.True(t, IsOfType(Classify(context.Canceled), Interrupted))
+		require.True(t, IsOfType(Classify(io.ErrUnexpectedEOF), IllegalFormat))
+	})
+
+	t.Run("User", func(t *testing.T) {
+		err := Classify(classifyTestTypeErr)
+		require.True(t, IsOfType(err, classifyTestType))
+		require.Equal(t, "classify.type: classified type", err.Error())
+		require.Equal(t, classifyTestTypeErr, Cast(err).Cause())
+	})
+
+	t.Run("Checked", func(t *testing.T) {
+		require.True(t, IsOfType(context.Canceled, Interrupted))
+		require.True(t, IsOfType(classifyTestTypeErr, classifyTestType))
+		require.False(t, IsOfType(io.EOF, IllegalFormat))
+		require.Equal(t, TimeoutElapsed, TypeSwitch(context.DeadlineExceeded, Interrupted, TimeoutElapsed))
+		require.Equal(t, NotRecognisedType(), TypeSwitch(io.EOF, IllegalFormat))
+	})
+
+	t.Run("Decorated", func(t *testing.T) {
+		err := Decorate(io.ErrUnexpectedEOF, "decorated")
+		require.True(t, err.IsOfType(IllegalFormat))
+		require.Equal(t, IllegalFormat, err.Type())
+		require.Equal(t, IllegalFormat, TypeSwitch(err, Interrupted, IllegalFormat))
+		require.Equal(t, foreignType, Decorate(io.EOF, "decorated").Type())
+
+		wrapped := testType.Wrap(io.ErrUnexpectedEOF, "wrapped")
+		require.False(t, wrapped.IsOfType(IllegalFormat))
+		require.True(t, testType.Wrap(Classify(io.ErrUnexpectedEOF), "wrapped").IsOfType(testType))
+	})
+
+	t.Run("AsIs", func(t *testing.T) {
+		require.Nil(t, Classify(nil))
+		require.Equal(t, io.EOF, Classify(io.EOF))
+
+		err := testType.New("test")
+		require.Equal(t, err, Classify(err))
+	})
+
+	t.Run("Precedence", func(t *testing.T) {
+		overridden := errors.New("overridden")
+		for _, errorType := range []*Type{IllegalState, DataUnavailable} {
+			errorType := errorType
+			RegisterTypeClassifier(func(err error) *Type {
+				if err == overridden {
+					return errorType
+				}
+				return nil
+			})
+		}
+		require.True(t, IsOfType(Classify(overridden), DataUnavailable))
+	})
+}
+
+func TestClassifyReentrant(t *testing.T) {
+	reentrant := errors.New("reentrant")
+	registered := make(chan struct{})
+	RegisterTraitClassifier(classifyTestTrait, func(err error) bool {
+		if err != reentrant {
+			return false
+		}
+
+		// a classifier checks another error while a registration is pending
+		go func() {
+			RegisterTraitClassifier(classifyTestTrait, func(error) bool { return false })
+			close(registered)
+		}()
+		time.Sleep(10 * time.Millisecond)
+		return IsTimeout(classifyTestTimeout{})
+	})
+
+	done := make(chan bool)
+	go func() {
+		done <- HasTrait(reentrant, classifyTestTrait)
+	}()
+
+	select {
+	case result := <-done:
+		require.True(t, result)
+		<-registered
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "classifier deadlocked")
+	}
+}
@@ -0,0 +1,72 @@
+// Command errorx-catalog documents the errorx declarations of a set of Go packages.
+//
+// It extracts all namespaces, types, traits and properties declared with errorx,
+// that is, by NewNamespace, NewSubNamespace, NewType, NewSubtype, ApplyModifiers, WithCode,
+// RegisterTrait, RegisterProperty and RegisterPrintableProperty calls,
+// and outputs a tree of namespaces and types along with the codes, traits and modifiers in effect for each of them.
+// The analysis is static, so no code of the packages is run; as a consequence, only the declarations with constant names are recognised.
+//
+// Usage:
+//
+//	errorx-catalog [-format markdown|json] [-deps] [-o file] [packages]
+//
+// Packages are specified as for the go tool, the current directory by default.
+// With -deps, declarations from all dependencies are included as well, which documents every error type a binary may produce.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/joomcode/errorx/cmd/internal/catalog"
+)
+
+func main() {
+	format := flag.String("format", "markdown", "output format, either markdown or json")
+	output := flag.String("o", "", "output file, standard output by default")
+	deps := flag.Bool("deps", false, "include declarations from dependencies")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: errorx-catalog [flags] [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*format, *output, *deps, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "errorx-catalog:", err)
+		os.Exit(1)
+	}
+}
+
+func run(format, output string, deps bool, patterns []string) error {
+	var write func(w io.Writer, c *catalog.Catalog) error
+	switch format {
+	case "markdown":
+		write = catalog.WriteMarkdown
+	case "json":
+		write = catalog.WriteJSON
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	c, err := catalog.Load("", deps, patterns...)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		return write(os.Stdout, c)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file, c); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,441 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generator renders the Go source for a spec, resolving and validating all the names on the way
+type generator struct {
+	spec       *Spec
+	b          strings.Builder
+	goNames    map[string]string
+	traits     map[string]string
+	properties map[string]*PropertySpec
+	codes      map[string]string
+	// statuses lists the types with an HTTP status, each subtype before its supertype
+	statuses []*TypeSpec
+}
+
+// generate produces formatted Go source for a spec.
+func generate(spec *Spec) ([]byte, error) {
+	g := &generator{
+		spec:       spec,
+		goNames:    make(map[string]string),
+		traits:     make(map[string]string),
+		properties: make(map[string]*PropertySpec),
+		codes:      make(map[string]string),
+	}
+	if err := g.resolve(); err != nil {
+		return nil, err
+	}
+
+	g.render()
+	source, err := format.Source([]byte(g.b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %v", err)
+	}
+	return source, nil
+}
+
+func (g *generator) resolve() error {
+	if !token.IsIdentifier(g.spec.Package) {
+		return fmt.Errorf("invalid package name %q", g.spec.Package)
+	}
+
+	for label, expr := range builtinTraits {
+		g.traits[label] = expr
+	}
+	for _, trait := range g.spec.Traits {
+		if err := g.declare("trait", trait.Name, trait.Name, &trait.GoName, goName(trait.Name)); err != nil {
+			return err
+		}
+		if _, ok := g.traits[trait.Name]; ok {
+			return fmt.Errorf("trait %q is declared more than once", trait.Name)
+		}
+		for _, implied := range trait.Implies {
+			if _, ok := g.traits[implied]; !ok {
+				return fmt.Errorf("trait %s: unknown trait %q, an implied trait must be declared before", trait.Name, implied)
+			}
+		}
+		g.traits[trait.Name] = trait.GoName
+	}
+
+	for _, property := range g.spec.Properties {
+		if err := g.declare("property", property.Name, property.Name, &property.GoName, "Property"+goName(property.Name)); err != nil {
+			return err
+		}
+		if _, ok := g.properties[property.Name]; ok {
+			return fmt.Errorf("property %q is declared more than once", property.Name)
+		}
+		if property.GoType == "" {
+			property.GoType = "interface{}"
+		}
+		g.properties[property.Name] = property
+	}
+
+	if err := g.resolveNamespaces(g.spec.Namespaces, ""); err != nil {
+		return err
+	}
+	if len(g.statuses) > 0 {
+		return g.declare("function", "HTTPStatus", "HTTPStatus", new(string), "HTTPStatus")
+	}
+	return nil
+}
+
+func (g *generator) resolveNamespaces(namespaces []*NamespaceSpec, parentName string) error {
+	for _, namespace := range namespaces {
+		fullName := joinName(parentName, namespace.Name)
+		if err := g.declare("namespace", namespace.Name, fullName, &namespace.GoName, goName(fullName)+"Errors"); err != nil {
+			return err
+		}
+		if err := g.checkTraitsAndModifiers("namespace "+fullName, namespace.Traits, namespace.Modifiers); err != nil {
+			return err
+		}
+		if err := g.resolveTypes(namespace.Types, fullName); err != nil {
+			return err
+		}
+		if err := g.resolveNamespaces(namespace.Namespaces, fullName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) resolveTypes(types []*TypeSpec, parentName string) error {
+	for _, t := range types {
+		fullName := joinName(parentName, t.Name)
+		if err := g.declare("type", t.Name, fullName, &t.GoName, goName(fullName)); err != nil {
+			return err
+		}
+		if _, ok := g.goNames["New"+t.GoName]; ok {
+			return fmt.Errorf("constructor New%s of type %s conflicts with another declaration", t.GoName, fullName)
+		}
+		g.goNames["New"+t.GoName] = "constructor of type " + fullName
+
+		if err := g.checkTraitsAndModifiers("type "+fullName, t.Traits, t.Modifiers); err != nil {
+			return err
+		}
+		if t.Code != "" {
+			if other, ok := g.codes[t.Code]; ok {
+				return fmt.Errorf("type %s: code %q is already assigned to type %s", fullName, t.Code, other)
+			}
+			g.codes[t.Code] = fullName
+		}
+		if t.HTTPStatus != 0 && (t.HTTPStatus < 100 || t.HTTPStatus > 599) {
+			return fmt.Errorf("type %s: invalid HTTP status %d", fullName, t.HTTPStatus)
+		}
+
+		params := make(map[string]bool)
+		for _, property := range t.Properties {
+			if _, ok := g.properties[property]; !ok {
+				return fmt.Errorf("type %s: unknown property %q", fullName, property)
+			}
+			if params[property] {
+				return fmt.Errorf("type %s: property %q is listed more than once", fullName, property)
+			}
+			if t.Message == "" && paramName(property) == messageParam {
+				return fmt.Errorf("type %s: property %q conflicts with the message parameter of the constructor, a message is to be provided", fullName, property)
+			}
+			params[property] = true
+		}
+		if err := g.resolveTemplate(t, fullName, params); err != nil {
+			return err
+		}
+
+		if err := g.resolveTypes(t.Subtypes, fullName); err != nil {
+			return err
+		}
+		if t.HTTPStatus != 0 {
+			g.statuses = append(g.statuses, t)
+		}
+	}
+	return nil
+}
+
+// messageParam is a name of a constructor parameter for a message of a type which has none in the spec
+const messageParam = "message"
+
+// resolveTemplate checks the placeholders of a type message, if any, which makes it a message template
+func (g *generator) resolveTemplate(t *TypeSpec, fullName string, params map[string]bool) error {
+	labels, err := placeholders(t.Message)
+	if err != nil {
+		return fmt.Errorf("type %s: %v", fullName, err)
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+
+	for _, label := range labels {
+		if !params[label] {
+			return fmt.Errorf("type %s: placeholder {%s} is not one of the type properties", fullName, label)
+		}
+	}
+	if _, ok := g.goNames[t.GoName+"Template"]; ok {
+		return fmt.Errorf("message template %sTemplate of type %s conflicts with another declaration", t.GoName, fullName)
+	}
+	g.goNames[t.GoName+"Template"] = "message template of type " + fullName
+	t.template = true
+	return nil
+}
+
+// declare checks a name of an entity and assigns a unique Go name to it
+func (g *generator) declare(kind, name, fullName string, goName *string, defaultGoName string) error {
+	if name == "" || strings.ContainsAny(name, ". \t\n") {
+		return fmt.Errorf("%s %q: invalid name", kind, fullName)
+	}
+	if *goName == "" {
+		*goName = defaultGoName
+	}
+	if !token.IsIdentifier(*goName) || !token.IsExported(*goName) {
+		return fmt.Errorf("%s %s: invalid Go name %q, an exported identifier expected", kind, fullName, *goName)
+	}
+	if other, ok := g.goNames[*goName]; ok {
+		return fmt.Errorf("%s %s: Go name %s is already used by %s", kind, fullName, *goName, other)
+	}
+	g.goNames[*goName] = kind + " " + fullName
+	return nil
+}
+
+func (g *generator) checkTraitsAndModifiers(entity string, traits []string, typeModifiers []string) error {
+	for _, trait := range traits {
+		if _, ok := g.traits[trait]; !ok {
+			return fmt.Errorf("%s: unknown trait %q", entity, trait)
+		}
+	}
+	for _, modifier := range typeModifiers {
+		if _, ok := modifiers[modifier]; !ok {
+			return fmt.Errorf("%s: unknown modifier %q", entity, modifier)
+		}
+	}
+	return nil
+}
+
+func (g *generator) render() {
+	g.printf("// Code generated by errorx-gen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", g.spec.Package)
+
+	var stdImports, imports []string
+	for _, path := range append([]string{"github.com/joomcode/errorx"}, g.spec.Imports...) {
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			imports = append(imports, strconv.Quote(path))
+		} else {
+			stdImports = append(stdImports, strconv.Quote(path))
+		}
+	}
+	sort.Strings(stdImports)
+	sort.Strings(imports)
+	if len(stdImports) > 0 {
+		imports = append(append(stdImports, ""), imports...)
+	}
+	g.printf("import (\n%s\n)\n", strings.Join(imports, "\n"))
+
+	if len(g.spec.Traits) > 0 {
+		g.printf("\nvar (\n")
+		for _, trait := range g.spec.Traits {
+			g.comment(trait.GoName+" is a trait "+trait.Name+".", trait.Description)
+			args := []string{strconv.Quote(trait.Name)}
+			if len(trait.Implies) > 0 {
+				args = append(args, "errorx.Implies("+strings.Join(g.traitArgs(trait.Implies), ", ")+")")
+			}
+			g.printf("%s = errorx.RegisterTrait(%s)\n", trait.GoName, strings.Join(args, ", "))
+		}
+		g.printf(")\n")
+	}
+
+	if len(g.spec.Properties) > 0 {
+		g.printf("\nvar (\n")
+		for _, property := range g.spec.Properties {
+			g.comment(property.GoName+" is a property "+property.Name+".", property.Description)
+			register := "RegisterPrintableProperty"
+			if property.Printable != nil && !*property.Printable {
+				register = "RegisterProperty"
+			}
+			args := strconv.Quote(property.Name)
+			if property.Sensitive {
+				args += ", errorx.PropertyModifierSensitive"
+			}
+			if property.Sticky {
+				args += ", errorx.PropertyModifierSticky"
+			}
+			g.printf("%s = errorx.%s(%s)\n", property.GoName, register, args)
+		}
+		g.printf(")\n")
+	}
+
+	if len(g.spec.Namespaces) > 0 {
+		g.printf("\nvar (\n")
+		g.renderNamespaces(g.spec.Namespaces, nil, "")
+		g.printf(")\n")
+		g.renderConstructors(g.spec.Namespaces, "")
+	}
+
+	if len(g.statuses) > 0 {
+		g.renderHTTPStatus()
+	}
+}
+
+func (g *generator) renderNamespaces(namespaces []*NamespaceSpec, parent *NamespaceSpec, parentName string) {
+	for _, namespace := range namespaces {
+		fullName := joinName(parentName, namespace.Name)
+		g.comment(namespace.GoName+" is a namespace "+fullName+".", namespace.Description)
+
+		args := append([]string{strconv.Quote(namespace.Name)}, g.traitArgs(namespace.Traits)...)
+		if parent == nil {
+			g.printf("%s = errorx.NewNamespace(%s)", namespace.GoName, strings.Join(args, ", "))
+		} else {
+			g.printf("%s = %s.NewSubNamespace(%s)", namespace.GoName, parent.GoName, strings.Join(args, ", "))
+		}
+		g.printf("%s\n", modifierCall(namespace.Modifiers))
+
+		g.renderTypes(namespace.Types, namespace.GoName+".NewType", fullName)
+		g.renderNamespaces(namespace.Namespaces, namespace, fullName)
+	}
+}
+
+func (g *generator) renderTypes(types []*TypeSpec, declare string, parentName string) {
+	for _, t := range types {
+		fullName := joinName(parentName, t.Name)
+		g.comment(t.GoName+" is an error type "+fullName+".", t.Description)
+
+		args := append([]string{strconv.Quote(t.Name)}, g.traitArgs(t.Traits)...)
+		g.printf("%s = %s(%s)%s", t.GoName, declare, strings.Join(args, ", "), modifierCall(t.Modifiers))
+		if t.Code != "" {
+			g.printf(".WithCode(%s)", strconv.Quote(t.Code))
+		}
+		g.printf("\n")
+
+		if t.template {
+			args := []string{strconv.Quote(t.Message)}
+			for _, label := range t.Properties {
+				args = append(args, g.properties[label].GoName)
+			}
+			g.printf("// %sTemplate is a message template of type %s.\n", t.GoName, fullName)
+			g.printf("%sTemplate = errorx.NewMessageTemplate(%s)\n", t.GoName, strings.Join(args, ", "))
+		}
+
+		g.renderTypes(t.Subtypes, t.GoName+".NewSubtype", fullName)
+	}
+}
+
+func (g *generator) renderConstructors(namespaces []*NamespaceSpec, parentName string) {
+	var renderTypes func(types []*TypeSpec, parentName string)
+	renderTypes = func(types []*TypeSpec, parentName string) {
+		for _, t := range types {
+			fullName := joinName(parentName, t.Name)
+
+			var params, args []string
+			if t.Message == "" {
+				params = append(params, messageParam+" string")
+			}
+			for _, label := range t.Properties {
+				params = append(params, paramName(label)+" "+g.properties[label].GoType)
+				args = append(args, paramName(label))
+			}
+
+			g.printf("\n// New%s creates a new error of type %s.\n", t.GoName, fullName)
+			g.printf("func New%s(%s) *errorx.Error {\n", t.GoName, strings.Join(params, ", "))
+			if t.template {
+				g.printf("return %s.NewFromTemplate(%s)", t.GoName, strings.Join(append([]string{t.GoName + "Template"}, args...), ", "))
+			} else {
+				message := messageParam
+				if t.Message != "" {
+					message = strconv.Quote(t.Message)
+				}
+				g.printf("return %s.New(%s)", t.GoName, message)
+				for _, label := range t.Properties {
+					g.printf(".\nWithProperty(%s, %s)", g.properties[label].GoName, paramName(label))
+				}
+			}
+			g.printf("\n}\n")
+
+			renderTypes(t.Subtypes, fullName)
+		}
+	}
+
+	for _, namespace := range namespaces {
+		fullName := joinName(parentName, namespace.Name)
+		renderTypes(namespace.Types, fullName)
+		g.renderConstructors(namespace.Namespaces, fullName)
+	}
+}
+
+func (g *generator) renderHTTPStatus() {
+	var types []string
+	for _, t := range g.statuses {
+		types = append(types, t.GoName)
+	}
+
+	g.printf("\n// HTTPStatus returns a status code of an HTTP response for an error of a type declared in this package, or 0 for any other error.\n")
+	g.printf("// A subtype inherits a status of its supertype unless it has its own.\n")
+	g.printf("func HTTPStatus(err error) int {\n")
+	g.printf("switch errorx.TypeSwitch(err, %s) {\n", strings.Join(types, ", "))
+	for _, t := range g.statuses {
+		g.printf("case %s:\nreturn %d\n", t.GoName, t.HTTPStatus)
+	}
+	g.printf("default:\nreturn 0\n}\n}\n")
+}
+
+func (g *generator) traitArgs(traits []string) []string {
+	var args []string
+	for _, trait := range traits {
+		args = append(args, g.traits[trait])
+	}
+	return args
+}
+
+func modifierCall(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	var args []string
+	for _, name := range names {
+		args = append(args, modifiers[name])
+	}
+	return ".ApplyModifiers(" + strings.Join(args, ", ") + ")"
+}
+
+func (g *generator) comment(summary, description string) {
+	g.printf("// %s\n", summary)
+	if description = strings.TrimSpace(description); description != "" {
+		for _, line := range strings.Split(description, "\n") {
+			g.printf("// %s\n", strings.TrimSpace(line))
+		}
+	}
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.b, format, args...)
+}
+
+// placeholders lists the labels of placeholders in a message, such as 'user {user_id} not found', with '{{' and '}}' for literal braces
+func placeholders(message string) ([]string, error) {
+	var labels []string
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		switch {
+		case (c == '{' || c == '}') && i+1 < len(message) && message[i+1] == c:
+			i++
+		case c == '{':
+			end := strings.IndexByte(message[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed placeholder in message %q", message)
+			}
+			labels = append(labels, message[i+1:i+end])
+			i += end
+		}
+	}
+	return labels, nil
+}
+
+func joinName(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
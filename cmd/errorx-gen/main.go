@@ -0,0 +1,81 @@
+// Command errorx-gen generates errorx declarations from a spec file.
+//
+// A spec in YAML or JSON format lists traits, properties, and a tree of namespaces and types along with their traits, modifiers and codes.
+// The generated code declares all of those, and a constructor for each type which takes a value for each of its properties:
+//
+//	package: users
+//	properties:
+//	  - name: user_id
+//	    go_type: int64
+//	namespaces:
+//	  - name: user
+//	    types:
+//	      - name: not_found
+//	        code: U404
+//	        http_status: 404
+//	        message: user {user_id} not found
+//	        traits: [not_found]
+//	        properties: [user_id]
+//
+// The spec above produces the UserErrors namespace, the UserNotFound type, the PropertyUserID property,
+// the NewUserNotFound(userID int64) constructor and the HTTPStatus(err error) int function.
+// A type without a message has a constructor which takes a message first, such as NewUserNotFound(message string, userID int64).
+// Properties are printable unless stated otherwise.
+// Go names are derived from errorx names, and may also be provided explicitly with go_name.
+//
+// Usage:
+//
+//	errorx-gen [-o file] [-package name] spec.yaml
+//
+// It is meant to be run by go generate:
+//
+//	//go:generate errorx-gen -o errors_gen.go errors.yaml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	output := flag.String("o", "", "output file, standard output by default")
+	pkg := flag.String("package", "", "package name, overrides the one in the spec")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: errorx-gen [flags] spec\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *output, *pkg); err != nil {
+		fmt.Fprintln(os.Stderr, "errorx-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, output, pkg string) error {
+	spec, err := loadSpec(specPath)
+	if err != nil {
+		return err
+	}
+	if pkg != "" {
+		spec.Package = pkg
+	}
+
+	source, err := generate(spec)
+	if err != nil {
+		return fmt.Errorf("%s: %v", specPath, err)
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	return ioutil.WriteFile(output, source, 0644)
+}
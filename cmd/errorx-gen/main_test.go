@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate(t *testing.T) {
+	spec, err := loadSpec("testdata/users/errors.yaml")
+	require.NoError(t, err)
+
+	source, err := generate(spec)
+	require.NoError(t, err)
+
+	golden := filepath.Join("testdata", "users", "errors_gen.go")
+	if *update {
+		require.NoError(t, ioutil.WriteFile(golden, source, 0644))
+	}
+
+	expected, err := ioutil.ReadFile(golden)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(source))
+
+	t.Run("Compiles", func(t *testing.T) {
+		cfg := &packages.Config{Mode: packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax, Dir: "testdata/users"}
+		pkgs, err := packages.Load(cfg, ".")
+		require.NoError(t, err)
+		require.Len(t, pkgs, 1)
+		require.Empty(t, pkgs[0].Errors)
+
+		constructor := pkgs[0].Types.Scope().Lookup("NewThrottled")
+		require.NotNil(t, constructor)
+		require.Equal(t, "func(message string, userID int64, retryAfter time.Duration, request interface{}, requestID string) *github.com/joomcode/errorx.Error", constructor.Type().String())
+
+		status := pkgs[0].Types.Scope().Lookup("HTTPStatus")
+		require.NotNil(t, status)
+		require.Equal(t, "func(err error) int", status.Type().String())
+	})
+}
+
+func TestLoadSpecJSON(t *testing.T) {
+	spec, err := loadSpec("testdata/spec.json")
+	require.NoError(t, err)
+
+	source, err := generate(spec)
+	require.NoError(t, err)
+	require.Contains(t, string(source), "OrderDuplicate = OrderErrors.NewType(\"duplicate\", errorx.Duplicate())\n")
+	require.Contains(t, string(source), "func NewOrderDuplicate(message string, orderID string) *errorx.Error {\n")
+	require.NotContains(t, string(source), "HTTPStatus")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  string
+		error string
+	}{
+		{"NoPackage", "namespaces: [{name: a}]", `invalid package name ""`},
+		{"DottedName", "package: p\nnamespaces: [{name: a.b}]", `namespace "a.b": invalid name`},
+		{"UnknownTrait", "package: p\nnamespaces: [{name: a, types: [{name: t, traits: [retryable]}]}]", `type a.t: unknown trait "retryable"`},
+		{"UnknownImpliedTrait", "package: p\ntraits: [{name: a, implies: [b]}, {name: b}]", `trait a: unknown trait "b", an implied trait must be declared before`},
+		{"UnknownModifier", "package: p\nnamespaces: [{name: a, modifiers: [opaque]}]", `namespace a: unknown modifier "opaque"`},
+		{"UnknownProperty", "package: p\nnamespaces: [{name: a, types: [{name: t, properties: [id]}]}]", `type a.t: unknown property "id"`},
+		{"GoNameConflict", "package: p\nnamespaces: [{name: a, types: [{name: b_c}]}, {name: a_b, types: [{name: c}]}]", `type a_b.c: Go name ABC is already used by type a.b_c`},
+		{"CodeConflict", "package: p\nnamespaces: [{name: a, types: [{name: t, code: E1}, {name: u, code: E1}]}]", `type a.u: code "E1" is already assigned to type a.t`},
+		{"UnknownPlaceholder", "package: p\nproperties: [{name: id}]\nnamespaces: [{name: a, types: [{name: t, message: '{id} {name}', properties: [id]}]}]", `type a.t: placeholder {name} is not one of the type properties`},
+		{"UnclosedPlaceholder", "package: p\nnamespaces: [{name: a, types: [{name: t, message: 'bad {id'}]}]", `type a.t: unclosed placeholder in message "bad {id"`},
+		{"InvalidHTTPStatus", "package: p\nnamespaces: [{name: a, types: [{name: t, http_status: 42}]}]", `type a.t: invalid HTTP status 42`},
+		{"HTTPStatusConflict", "package: p\nnamespaces: [{name: a, types: [{name: t, go_name: HTTPStatus, http_status: 404}]}]", `function HTTPStatus: Go name HTTPStatus is already used by type a.t`},
+		{"MessageConflict", "package: p\nproperties: [{name: message}]\nnamespaces: [{name: a, types: [{name: t, properties: [message]}]}]", `type a.t: property "message" conflicts with the message parameter`},
+		{"ConstructorConflict", "package: p\nnamespaces: [{name: a, types: [{name: t}, {name: new, go_name: NewAT}]}]", `Go name NewAT is already used by constructor of type a.t`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec, err := parseSpec([]byte(test.spec), false)
+			require.NoError(t, err)
+
+			_, err = generate(spec)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.error)
+		})
+	}
+}
+
+func TestParseSpecStrict(t *testing.T) {
+	_, err := parseSpec([]byte("package: p\nnamespaces: [{name: a, kind: b}]"), false)
+	require.Error(t, err)
+
+	_, err = parseSpec([]byte(`{"package": "p", "extra": true}`), true)
+	require.Error(t, err)
+}
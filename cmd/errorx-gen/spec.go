@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Spec describes the errorx declarations of a package.
+// Imports lists the packages referred to by the Go types of properties.
+type Spec struct {
+	Package    string           `json:"package" yaml:"package"`
+	Imports    []string         `json:"imports" yaml:"imports"`
+	Traits     []*TraitSpec     `json:"traits" yaml:"traits"`
+	Properties []*PropertySpec  `json:"properties" yaml:"properties"`
+	Namespaces []*NamespaceSpec `json:"namespaces" yaml:"namespaces"`
+}
+
+// TraitSpec describes a trait.
+// Implies lists the traits it implies, see errorx.Implies; those are either built-in or declared earlier in the spec.
+type TraitSpec struct {
+	Name        string   `json:"name" yaml:"name"`
+	GoName      string   `json:"go_name" yaml:"go_name"`
+	Description string   `json:"description" yaml:"description"`
+	Implies     []string `json:"implies" yaml:"implies"`
+}
+
+// PropertySpec describes a property, which is printable unless stated otherwise.
+// GoType is a type of a constructor parameter for this property, interface{} by default.
+// A sensitive property value is redacted in the output, see errorx.PropertyModifierSensitive.
+// A sticky property remains visible through an opaque wrap, see errorx.PropertyModifierSticky.
+type PropertySpec struct {
+	Name        string `json:"name" yaml:"name"`
+	GoName      string `json:"go_name" yaml:"go_name"`
+	GoType      string `json:"go_type" yaml:"go_type"`
+	Printable   *bool  `json:"printable" yaml:"printable"`
+	Sensitive   bool   `json:"sensitive" yaml:"sensitive"`
+	Sticky      bool   `json:"sticky" yaml:"sticky"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// NamespaceSpec describes a namespace along with its sub-namespaces and types.
+type NamespaceSpec struct {
+	Name        string           `json:"name" yaml:"name"`
+	GoName      string           `json:"go_name" yaml:"go_name"`
+	Description string           `json:"description" yaml:"description"`
+	Traits      []string         `json:"traits" yaml:"traits"`
+	Modifiers   []string         `json:"modifiers" yaml:"modifiers"`
+	Namespaces  []*NamespaceSpec `json:"namespaces" yaml:"namespaces"`
+	Types       []*TypeSpec      `json:"types" yaml:"types"`
+}
+
+// TypeSpec describes an error type along with its subtypes.
+// Code is a stable code of the type, if any, see errorx.Type.WithCode.
+// HTTPStatus is a status code of an HTTP response for an error of the type, if any, which is inherited by the subtypes, see HTTPStatus.
+// A constructor of the type takes a value for each of the properties, in order, and creates an error with the message;
+// without a message in the spec, a constructor takes a message as its first parameter.
+// A message with placeholders of the properties, such as 'user {user_id} not found', is a message template, see errorx.MessageTemplate;
+// use '{{' and '}}' for literal braces.
+type TypeSpec struct {
+	Name        string      `json:"name" yaml:"name"`
+	GoName      string      `json:"go_name" yaml:"go_name"`
+	Description string      `json:"description" yaml:"description"`
+	Code        string      `json:"code" yaml:"code"`
+	HTTPStatus  int         `json:"http_status" yaml:"http_status"`
+	Message     string      `json:"message" yaml:"message"`
+	Traits      []string    `json:"traits" yaml:"traits"`
+	Modifiers   []string    `json:"modifiers" yaml:"modifiers"`
+	Properties  []string    `json:"properties" yaml:"properties"`
+	Subtypes    []*TypeSpec `json:"subtypes" yaml:"subtypes"`
+
+	template bool
+}
+
+// loadSpec reads a spec file, which is JSON for a .json extension, and YAML otherwise.
+func loadSpec(path string) (*Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := parseSpec(data, strings.EqualFold(filepath.Ext(path), ".json"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return spec, nil
+}
+
+func parseSpec(data []byte, isJSON bool) (*Spec, error) {
+	spec := &Spec{}
+	if isJSON {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(spec); err != nil {
+			return nil, err
+		}
+	} else if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// builtinTraits maps the labels of errorx traits to their Go expressions
+var builtinTraits = map[string]string{
+	"temporary":     "errorx.Temporary()",
+	"timeout":       "errorx.Timeout()",
+	"not_found":     "errorx.NotFound()",
+	"duplicate":     "errorx.Duplicate()",
+	"runtime_fault": "errorx.RuntimeFault()",
+}
+
+// modifiers maps the names of type modifiers in a spec to their Go expressions
+var modifiers = map[string]string{
+	"transparent":        "errorx.TypeModifierTransparent",
+	"omit_stack_trace":   "errorx.TypeModifierOmitStackTrace",
+	"omit_cause_message": "errorx.TypeModifierOmitCauseMessage",
+}
+
+// goName converts a snake_case or dotted name into an exported CamelCase identifier, honoring common initialisms.
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '.' || r == '-' || r == ' ' }) {
+		if upper := strings.ToUpper(word); commonInitialisms[upper] {
+			b.WriteString(upper)
+		} else {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// paramName converts a name into an unexported identifier.
+func paramName(name string) string {
+	exported := goName(name)
+	for prefix := range commonInitialisms {
+		if strings.HasPrefix(exported, prefix) && (len(exported) == len(prefix) || strings.ToUpper(exported[len(prefix):len(prefix)+1]) == exported[len(prefix):len(prefix)+1]) {
+			exported = strings.ToLower(prefix) + exported[len(prefix):]
+			break
+		}
+	}
+	result := strings.ToLower(exported[:1]) + exported[1:]
+	if goKeywords[result] {
+		result += "Value"
+	}
+	return result
+}
+
+var commonInitialisms = map[string]bool{
+	"API": true, "HTTP": true, "ID": true, "IP": true, "JSON": true, "SQL": true, "TCP": true, "TLS": true,
+	"UDP": true, "UI": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true, "defer": true,
+	"else": true, "fallthrough": true, "for": true, "func": true, "go": true, "goto": true, "if": true,
+	"import": true, "interface": true, "map": true, "package": true, "range": true, "return": true,
+	"select": true, "struct": true, "switch": true, "type": true, "var": true,
+}
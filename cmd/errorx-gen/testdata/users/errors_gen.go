@@ -0,0 +1,86 @@
+// Code generated by errorx-gen. DO NOT EDIT.
+
+package users
+
+import (
+	"time"
+
+	"github.com/joomcode/errorx"
+)
+
+var (
+	// Retryable is a trait retryable.
+	// Operations failed with a retryable error may be repeated.
+	Retryable = errorx.RegisterTrait("retryable", errorx.Implies(errorx.Temporary()))
+)
+
+var (
+	// PropertyUserID is a property user_id.
+	PropertyUserID = errorx.RegisterPrintableProperty("user_id")
+	// PropertyRetryAfter is a property retry_after.
+	PropertyRetryAfter = errorx.RegisterPrintableProperty("retry_after")
+	// PropertyRequest is a property request.
+	PropertyRequest = errorx.RegisterProperty("request", errorx.PropertyModifierSensitive)
+	// PropertyRequestID is a property request_id.
+	PropertyRequestID = errorx.RegisterPrintableProperty("request_id", errorx.PropertyModifierSticky)
+)
+
+var (
+	// UserErrors is a namespace user.
+	// Errors of user management.
+	UserErrors = errorx.NewNamespace("user", Retryable)
+	// UserNotFound is an error type user.not_found.
+	UserNotFound = UserErrors.NewType("not_found", errorx.NotFound()).WithCode("U404")
+	// UserNotFoundTemplate is a message template of type user.not_found.
+	UserNotFoundTemplate = errorx.NewMessageTemplate("user {user_id} not found", PropertyUserID)
+	// UserNotFoundDeleted is an error type user.not_found.deleted.
+	// User was deleted.
+	// Deleted users are never restored.
+	UserNotFoundDeleted = UserNotFound.NewSubtype("deleted")
+	// Throttled is an error type user.throttled.
+	Throttled = UserErrors.NewType("throttled").ApplyModifiers(errorx.TypeModifierOmitStackTrace)
+	// UserAuthErrors is a namespace user.auth.
+	UserAuthErrors = UserErrors.NewSubNamespace("auth").ApplyModifiers(errorx.TypeModifierOmitStackTrace, errorx.TypeModifierOmitCauseMessage)
+	// UserAuthInvalidToken is an error type user.auth.invalid_token.
+	UserAuthInvalidToken = UserAuthErrors.NewType("invalid_token", errorx.Temporary())
+)
+
+// NewUserNotFound creates a new error of type user.not_found.
+func NewUserNotFound(userID int64) *errorx.Error {
+	return UserNotFound.NewFromTemplate(UserNotFoundTemplate, userID)
+}
+
+// NewUserNotFoundDeleted creates a new error of type user.not_found.deleted.
+func NewUserNotFoundDeleted(message string, userID int64) *errorx.Error {
+	return UserNotFoundDeleted.New(message).
+		WithProperty(PropertyUserID, userID)
+}
+
+// NewThrottled creates a new error of type user.throttled.
+func NewThrottled(message string, userID int64, retryAfter time.Duration, request interface{}, requestID string) *errorx.Error {
+	return Throttled.New(message).
+		WithProperty(PropertyUserID, userID).
+		WithProperty(PropertyRetryAfter, retryAfter).
+		WithProperty(PropertyRequest, request).
+		WithProperty(PropertyRequestID, requestID)
+}
+
+// NewUserAuthInvalidToken creates a new error of type user.auth.invalid_token.
+func NewUserAuthInvalidToken() *errorx.Error {
+	return UserAuthInvalidToken.New("invalid token")
+}
+
+// HTTPStatus returns a status code of an HTTP response for an error of a type declared in this package, or 0 for any other error.
+// A subtype inherits a status of its supertype unless it has its own.
+func HTTPStatus(err error) int {
+	switch errorx.TypeSwitch(err, UserNotFound, Throttled, UserAuthInvalidToken) {
+	case UserNotFound:
+		return 404
+	case Throttled:
+		return 429
+	case UserAuthInvalidToken:
+		return 401
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,7 @@
+module example.com/users
+
+go 1.25
+
+require github.com/joomcode/errorx v1.0.0
+
+replace github.com/joomcode/errorx => ../../../..
@@ -0,0 +1,16 @@
+// Command errorx-vet runs the errorx analyzers, see package errorxcheck.
+//
+// It is meant to be run by go vet:
+//
+//	go install github.com/joomcode/errorx/cmd/errorx-vet
+//	go vet -vettool=$(which errorx-vet) ./...
+package main
+
+import (
+	"github.com/joomcode/errorx/errorxcheck"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(errorxcheck.Analyzers...)
+}
@@ -0,0 +1,19 @@
+module github.com/joomcode/errorx/cmd
+
+go 1.25.0
+
+require (
+	github.com/joomcode/errorx/errorxcheck v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.4.0
+	golang.org/x/tools v0.47.0
+	gopkg.in/yaml.v2 v2.2.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.37.0 // indirect
+	golang.org/x/sync v0.21.0 // indirect
+)
+
+replace github.com/joomcode/errorx/errorxcheck => ../errorxcheck
@@ -0,0 +1,414 @@
+// Package catalog extracts errorx declarations from Go packages and renders them as a catalog.
+//
+// It only backs the errorx-catalog command and is not meant to be imported, so its API may change at will;
+// other tools are to consume the JSON output of the command instead.
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Catalog is a tree of namespaces and types along with all traits and properties.
+type Catalog struct {
+	Namespaces []*Namespace `json:"namespaces"`
+	Traits     []*Trait     `json:"traits"`
+	Properties []*Property  `json:"properties"`
+}
+
+// Declaration describes where an entity is declared.
+type Declaration struct {
+	GoName   string `json:"go_name,omitempty"`
+	Position string `json:"position"`
+	// External is set for an entity declared outside of the analyzed packages, which is listed only as a parent of another one
+	External bool `json:"external,omitempty"`
+}
+
+// Namespace is a namespace along with its sub-namespaces and types.
+// Traits and Modifiers are those in effect, inherited ones included.
+type Namespace struct {
+	Name              string   `json:"name"`
+	Traits            []string `json:"traits,omitempty"`
+	DeclaredTraits    []string `json:"declared_traits,omitempty"`
+	Modifiers         []string `json:"modifiers,omitempty"`
+	DeclaredModifiers []string `json:"declared_modifiers,omitempty"`
+	Declaration
+	Namespaces []*Namespace `json:"namespaces,omitempty"`
+	Types      []*Type      `json:"types,omitempty"`
+}
+
+// Type is an error type along with its subtypes.
+// Code, Traits and Modifiers are those in effect, inherited ones included.
+type Type struct {
+	Name              string   `json:"name"`
+	Code              string   `json:"code,omitempty"`
+	Traits            []string `json:"traits,omitempty"`
+	DeclaredTraits    []string `json:"declared_traits,omitempty"`
+	Modifiers         []string `json:"modifiers,omitempty"`
+	DeclaredModifiers []string `json:"declared_modifiers,omitempty"`
+	Declaration
+	Subtypes []*Type `json:"subtypes,omitempty"`
+}
+
+// Trait is a registered trait.
+// Implies lists the traits it implies directly, see errorx.Implies; those are included in the traits in effect of namespaces and types.
+type Trait struct {
+	Label   string   `json:"label"`
+	Implies []string `json:"implies,omitempty"`
+	Declaration
+}
+
+// Property is a registered property.
+type Property struct {
+	Label     string   `json:"label"`
+	Printable bool     `json:"printable"`
+	Modifiers []string `json:"modifiers,omitempty"`
+	Declaration
+}
+
+// Load extracts a catalog from the packages matching the patterns, which are resolved relative to dir.
+// Unless deps is set, only the declarations made in the matching packages are included.
+func Load(dir string, deps bool, patterns ...string) (*Catalog, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir:  dir,
+		Fset: token.NewFileSet(),
+	}
+	roots, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if packages.PrintErrors(roots) > 0 {
+		return nil, fmt.Errorf("failed to load packages")
+	}
+
+	rootSet := make(map[*packages.Package]bool)
+	for _, pkg := range roots {
+		rootSet[pkg] = true
+	}
+
+	x := newExtractor(cfg.Fset, func(pkg *packages.Package) bool {
+		return deps || rootSet[pkg]
+	})
+	x.extract(roots)
+	return newCatalog(x), nil
+}
+
+func newCatalog(x *extractor) *Catalog {
+	listed := make(map[interface{}]bool)
+	for _, t := range x.types {
+		if t.included {
+			for current := t; current != nil; current = current.parent {
+				listed[current] = true
+			}
+			for namespace := t.namespace; namespace != nil; namespace = namespace.parent {
+				listed[namespace] = true
+			}
+		}
+	}
+	for _, namespace := range x.namespaces {
+		if namespace.included {
+			for current := namespace; current != nil; current = current.parent {
+				listed[current] = true
+			}
+		}
+	}
+
+	namespaces := make(map[*namespaceEntry]*Namespace)
+	catalog := &Catalog{Namespaces: []*Namespace{}, Traits: []*Trait{}, Properties: []*Property{}}
+	for _, entry := range sortedNamespaces(x.namespaces) {
+		if !listed[entry] {
+			continue
+		}
+
+		namespace := &Namespace{
+			Name:              entry.name,
+			Traits:            traitLabels(withImplied(entry.effectiveTraits())),
+			DeclaredTraits:    traitLabels(entry.traits),
+			Modifiers:         entry.effectiveModifiers(),
+			DeclaredModifiers: entry.modifiers,
+			Declaration:       entry.declaration.export(),
+		}
+		namespaces[entry] = namespace
+
+		if parent, ok := namespaces[entry.parent]; ok {
+			parent.Namespaces = append(parent.Namespaces, namespace)
+		} else {
+			catalog.Namespaces = append(catalog.Namespaces, namespace)
+		}
+	}
+
+	types := make(map[*typeEntry]*Type)
+	for _, entry := range sortedTypes(x.types) {
+		if !listed[entry] {
+			continue
+		}
+
+		t := &Type{
+			Name:              entry.name,
+			Code:              entry.effectiveCode(),
+			Traits:            traitLabels(withImplied(entry.effectiveTraits())),
+			DeclaredTraits:    traitLabels(entry.traits),
+			Modifiers:         entry.effectiveModifiers(),
+			DeclaredModifiers: entry.modifiers,
+			Declaration:       entry.declaration.export(),
+		}
+		types[entry] = t
+
+		if parent, ok := types[entry.parent]; ok {
+			parent.Subtypes = append(parent.Subtypes, t)
+		} else if namespace, ok := namespaces[entry.namespace]; ok {
+			namespace.Types = append(namespace.Types, t)
+		}
+	}
+
+	for _, entry := range x.traits {
+		if entry.included {
+			catalog.Traits = append(catalog.Traits, &Trait{
+				Label:       entry.label,
+				Implies:     traitLabels(entry.implies),
+				Declaration: entry.declaration.export(),
+			})
+		}
+	}
+	sort.SliceStable(catalog.Traits, func(i, j int) bool { return catalog.Traits[i].Label < catalog.Traits[j].Label })
+
+	for _, entry := range x.properties {
+		if entry.included {
+			catalog.Properties = append(catalog.Properties, &Property{
+				Label:       entry.label,
+				Printable:   entry.printable,
+				Modifiers:   entry.modifiers,
+				Declaration: entry.declaration.export(),
+			})
+		}
+	}
+	sort.SliceStable(catalog.Properties, func(i, j int) bool { return catalog.Properties[i].Label < catalog.Properties[j].Label })
+
+	return catalog
+}
+
+// sortedNamespaces orders namespaces so that each parent precedes its children, and otherwise by declaration
+func sortedNamespaces(entries []*namespaceEntry) []*namespaceEntry {
+	result := append([]*namespaceEntry(nil), entries...)
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].name != result[j].name {
+			return result[i].name < result[j].name
+		}
+		return result[i].declaration.before(result[j].declaration)
+	})
+	return result
+}
+
+// sortedTypes orders types so that each parent precedes its subtypes, and otherwise by declaration
+func sortedTypes(entries []*typeEntry) []*typeEntry {
+	result := append([]*typeEntry(nil), entries...)
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].name != result[j].name {
+			return result[i].name < result[j].name
+		}
+		return result[i].declaration.before(result[j].declaration)
+	})
+	return result
+}
+
+func (d declaration) export() Declaration {
+	return Declaration{
+		GoName:   d.goName,
+		Position: d.position(),
+		External: !d.included,
+	}
+}
+
+func (n *namespaceEntry) effectiveTraits() []*traitEntry {
+	if n.parent == nil {
+		return n.traits
+	}
+	return appendTraits(n.parent.effectiveTraits(), n.traits...)
+}
+
+func (n *namespaceEntry) effectiveModifiers() []string {
+	if n.parent == nil {
+		return n.modifiers
+	}
+	return appendModifiers(n.parent.effectiveModifiers(), n.modifiers...)
+}
+
+func (t *typeEntry) effectiveTraits() []*traitEntry {
+	var result []*traitEntry
+	if t.parent != nil {
+		result = appendTraits(result, t.parent.effectiveTraits()...)
+	}
+	result = appendTraits(result, t.namespace.effectiveTraits()...)
+	return appendTraits(result, t.traits...)
+}
+
+func (t *typeEntry) effectiveModifiers() []string {
+	var result []string
+	if t.parent != nil {
+		result = appendModifiers(result, t.parent.effectiveModifiers()...)
+	} else {
+		result = appendModifiers(result, t.namespace.effectiveModifiers()...)
+	}
+	return appendModifiers(result, t.modifiers...)
+}
+
+func (t *typeEntry) effectiveCode() string {
+	for current := t; current != nil; current = current.parent {
+		if current.code != "" {
+			return current.code
+		}
+	}
+	return ""
+}
+
+func appendTraits(traits []*traitEntry, more ...*traitEntry) []*traitEntry {
+	result := append([]*traitEntry(nil), traits...)
+	for _, trait := range more {
+		duplicate := false
+		for _, known := range result {
+			duplicate = duplicate || known == trait
+		}
+		if !duplicate {
+			result = append(result, trait)
+		}
+	}
+	return result
+}
+
+// withImplied adds the traits implied by the listed ones, transitively
+func withImplied(traits []*traitEntry) []*traitEntry {
+	result := traits
+	for i := 0; i < len(result); i++ {
+		result = appendTraits(result, result[i].implies...)
+	}
+	return result
+}
+
+func appendModifiers(modifiers []string, more ...string) []string {
+	result := append([]string(nil), modifiers...)
+	for _, modifier := range more {
+		duplicate := false
+		for _, known := range result {
+			duplicate = duplicate || known == modifier
+		}
+		if !duplicate {
+			result = append(result, modifier)
+		}
+	}
+	return result
+}
+
+func traitLabels(traits []*traitEntry) []string {
+	var result []string
+	for _, trait := range traits {
+		result = append(result, trait.label)
+	}
+	return result
+}
+
+// WriteJSON writes a catalog as indented JSON.
+func WriteJSON(w io.Writer, catalog *Catalog) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(catalog)
+}
+
+// WriteMarkdown writes a catalog as a Markdown document.
+func WriteMarkdown(w io.Writer, catalog *Catalog) error {
+	var b strings.Builder
+	b.WriteString("# Error catalog\n")
+
+	b.WriteString("\n## Namespaces\n")
+	var writeNamespace func(namespace *Namespace)
+	writeNamespace = func(namespace *Namespace) {
+		fmt.Fprintf(&b, "\n### `%s`\n\n", namespace.Name)
+		fmt.Fprintf(&b, "Declared %s.\n", markdownDeclaration(namespace.Declaration))
+		if len(namespace.Traits) > 0 {
+			fmt.Fprintf(&b, "Traits: %s.\n", markdownList(namespace.Traits))
+		}
+		if len(namespace.Modifiers) > 0 {
+			fmt.Fprintf(&b, "Modifiers: %s.\n", markdownList(namespace.Modifiers))
+		}
+
+		if len(namespace.Types) > 0 {
+			b.WriteString("\n| Type | Code | Traits | Modifiers | Declared |\n| --- | --- | --- | --- | --- |\n")
+			var writeType func(t *Type)
+			writeType = func(t *Type) {
+				fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n",
+					t.Name, markdownList(codes(t.Code)), markdownList(t.Traits), markdownList(t.Modifiers), markdownDeclaration(t.Declaration))
+				for _, subtype := range t.Subtypes {
+					writeType(subtype)
+				}
+			}
+			for _, t := range namespace.Types {
+				writeType(t)
+			}
+		}
+
+		for _, child := range namespace.Namespaces {
+			writeNamespace(child)
+		}
+	}
+	for _, namespace := range catalog.Namespaces {
+		writeNamespace(namespace)
+	}
+
+	if len(catalog.Traits) > 0 {
+		b.WriteString("\n## Traits\n\n| Trait | Implies | Declared |\n| --- | --- | --- |\n")
+		for _, trait := range catalog.Traits {
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", trait.Label, markdownList(trait.Implies), markdownDeclaration(trait.Declaration))
+		}
+	}
+
+	if len(catalog.Properties) > 0 {
+		b.WriteString("\n## Properties\n\n| Property | Printable | Modifiers | Declared |\n| --- | --- | --- | --- |\n")
+		for _, property := range catalog.Properties {
+			printable := "no"
+			if property.Printable {
+				printable = "yes"
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", property.Label, printable, markdownList(property.Modifiers), markdownDeclaration(property.Declaration))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func codes(code string) []string {
+	if code == "" {
+		return nil
+	}
+	return []string{code}
+}
+
+func markdownList(items []string) string {
+	quoted := make([]string, 0, len(items))
+	for _, item := range items {
+		quoted = append(quoted, "`"+item+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func markdownDeclaration(d Declaration) string {
+	result := "at " + d.Position
+	if d.GoName != "" {
+		result = "as `" + d.GoName + "` " + result
+	}
+	if d.External {
+		result += " (external)"
+	}
+	return result
+}
@@ -0,0 +1,131 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureDir = "testdata/fixture"
+
+func TestLoad(t *testing.T) {
+	catalog, err := Load(fixtureDir, false, "./errs")
+	require.NoError(t, err)
+
+	t.Run("Namespaces", func(t *testing.T) {
+		require.Len(t, catalog.Namespaces, 2)
+
+		common := catalog.Namespaces[0]
+		require.Equal(t, "common", common.Name)
+		require.True(t, common.External)
+		require.Equal(t, "errorx.CommonErrors", common.GoName)
+
+		storage := catalog.Namespaces[1]
+		require.Equal(t, "storage", storage.Name)
+		require.False(t, storage.External)
+		require.Equal(t, "errs.Storage", storage.GoName)
+		require.Equal(t, "example.com/fixture/errs/errs.go:10", storage.Position)
+		require.Equal(t, []string{"retryable", "temporary"}, storage.Traits)
+		require.Equal(t, []string{"retryable"}, storage.DeclaredTraits)
+
+		require.Len(t, storage.Namespaces, 1)
+		cache := storage.Namespaces[0]
+		require.Equal(t, "storage.cache", cache.Name)
+		require.Equal(t, []string{"retryable", "temporary"}, cache.Traits)
+		require.Nil(t, cache.DeclaredTraits)
+	})
+
+	t.Run("Types", func(t *testing.T) {
+		storage := catalog.Namespaces[1]
+		require.Len(t, storage.Types, 1)
+
+		conflict := storage.Types[0]
+		require.Equal(t, "storage.conflict", conflict.Name)
+		require.Equal(t, []string{"retryable", "duplicate", "temporary"}, conflict.Traits)
+		require.Equal(t, []string{"duplicate"}, conflict.DeclaredTraits)
+
+		require.Len(t, conflict.Subtypes, 1)
+		stale := conflict.Subtypes[0]
+		require.Equal(t, "storage.conflict.stale", stale.Name)
+		require.Equal(t, "errs.Stale", stale.GoName)
+		require.Equal(t, []string{"retryable", "duplicate", "temporary"}, stale.Traits)
+		require.Equal(t, []string{"OmitStackTrace"}, stale.Modifiers)
+		require.Equal(t, "S409", conflict.Code)
+		require.Equal(t, "S409", stale.Code)
+
+		cache := storage.Namespaces[0]
+		require.Len(t, cache.Types, 1)
+		require.Equal(t, "storage.cache.miss", cache.Types[0].Name)
+		require.Empty(t, cache.Types[0].Code)
+		require.Equal(t, []string{"Audited"}, cache.Types[0].Modifiers)
+	})
+
+	t.Run("ExternalParents", func(t *testing.T) {
+		common := catalog.Namespaces[0]
+		require.Len(t, common.Types, 1)
+
+		timeout := common.Types[0]
+		require.Equal(t, "common.timeout", timeout.Name)
+		require.True(t, timeout.External)
+
+		require.Len(t, timeout.Subtypes, 1)
+		require.Equal(t, "common.timeout.storage_timeout", timeout.Subtypes[0].Name)
+		require.False(t, timeout.Subtypes[0].External)
+		require.Equal(t, []string{"timeout"}, timeout.Subtypes[0].Traits)
+	})
+
+	t.Run("TraitsAndProperties", func(t *testing.T) {
+		require.Len(t, catalog.Traits, 1)
+		require.Equal(t, "retryable", catalog.Traits[0].Label)
+		require.Equal(t, "errs.Retryable", catalog.Traits[0].GoName)
+		require.Equal(t, []string{"temporary"}, catalog.Traits[0].Implies)
+
+		require.Len(t, catalog.Properties, 2)
+		require.Equal(t, "attempt", catalog.Properties[0].Label)
+		require.True(t, catalog.Properties[0].Printable)
+		require.Empty(t, catalog.Properties[0].Modifiers)
+		require.Equal(t, "token", catalog.Properties[1].Label)
+		require.False(t, catalog.Properties[1].Printable)
+		require.Equal(t, []string{"Sensitive"}, catalog.Properties[1].Modifiers)
+	})
+}
+
+func TestLoadDeps(t *testing.T) {
+	catalog, err := Load(fixtureDir, true, "./...")
+	require.NoError(t, err)
+
+	names := make(map[string]bool)
+	for _, namespace := range catalog.Namespaces {
+		names[namespace.Name] = namespace.External
+	}
+	require.Equal(t, map[string]bool{"common": false, "ignored": false, "storage": false, "synthetic": false}, names)
+}
+
+func TestWrite(t *testing.T) {
+	catalog, err := Load(fixtureDir, false, "./errs")
+	require.NoError(t, err)
+
+	t.Run("JSON", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, WriteJSON(&b, catalog))
+
+		var decoded Catalog
+		require.NoError(t, json.Unmarshal(b.Bytes(), &decoded))
+		require.Equal(t, catalog, &decoded)
+		require.Contains(t, b.String(), `"go_name": "errs.Storage"`)
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, WriteMarkdown(&b, catalog))
+
+		output := b.String()
+		require.Contains(t, output, "### `storage.cache`\n")
+		require.Contains(t, output, "Declared as `errorx.CommonErrors` at github.com/joomcode/errorx/common.go:7 (external).\n")
+		require.Contains(t, output, "| `storage.conflict.stale` | `S409` | `retryable`, `duplicate`, `temporary` | `OmitStackTrace` | as `errs.Stale` at example.com/fixture/errs/errs.go:13 |\n")
+		require.Contains(t, output, "| `retryable` | `temporary` | as `errs.Retryable` at example.com/fixture/errs/errs.go:6 |\n")
+		require.Contains(t, output, "| `attempt` | yes |  | as `errs.Attempt` at example.com/fixture/errs/errs.go:7 |\n")
+	})
+}
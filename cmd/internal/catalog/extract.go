@@ -0,0 +1,520 @@
+package catalog
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const errorxPath = "github.com/joomcode/errorx"
+
+type namespaceEntry struct {
+	name      string
+	parent    *namespaceEntry
+	traits    []*traitEntry
+	modifiers []string
+	declaration
+}
+
+type typeEntry struct {
+	name      string
+	code      string
+	namespace *namespaceEntry
+	parent    *typeEntry
+	traits    []*traitEntry
+	modifiers []string
+	declaration
+}
+
+type traitEntry struct {
+	label   string
+	implies []*traitEntry
+	declaration
+}
+
+// modifierEntry is a type modifier declared with errorx.RegisterTypeModifier()
+type modifierEntry struct {
+	name string
+}
+
+// impliesEntry is a value of errorx.Implies(), which is only meaningful as an argument of errorx.RegisterTrait()
+type impliesEntry struct {
+	traits []*traitEntry
+}
+
+type propertyEntry struct {
+	label     string
+	printable bool
+	modifiers []string
+	declaration
+}
+
+// declaration describes where an entity is declared
+type declaration struct {
+	goName   string
+	file     string
+	line     int
+	column   int
+	included bool
+}
+
+func (d declaration) position() string {
+	return d.file + ":" + strconv.Itoa(d.line)
+}
+
+func (d declaration) before(other declaration) bool {
+	if d.file != other.file {
+		return d.file < other.file
+	}
+	if d.line != other.line {
+		return d.line < other.line
+	}
+	return d.column < other.column
+}
+
+type varDecl struct {
+	pkg  *packages.Package
+	expr ast.Expr
+}
+
+type funcDecl struct {
+	pkg  *packages.Package
+	decl *ast.FuncDecl
+}
+
+// extractor evaluates errorx declarations statically.
+// Package variables and calls of functions that merely return a value are followed,
+// so that a declaration may refer to namespaces, types and traits declared elsewhere, errorx package included.
+// Names and labels must be constant.
+type extractor struct {
+	fset       *token.FileSet
+	included   func(pkg *packages.Package) bool
+	vars       map[*types.Var]varDecl
+	funcs      map[*types.Func]funcDecl
+	values     map[types.Object]interface{}
+	calls      map[*ast.CallExpr]interface{}
+	evaluating map[types.Object]bool
+
+	namespaces []*namespaceEntry
+	types      []*typeEntry
+	traits     []*traitEntry
+	properties []*propertyEntry
+}
+
+func newExtractor(fset *token.FileSet, included func(pkg *packages.Package) bool) *extractor {
+	return &extractor{
+		fset:       fset,
+		included:   included,
+		vars:       make(map[*types.Var]varDecl),
+		funcs:      make(map[*types.Func]funcDecl),
+		values:     make(map[types.Object]interface{}),
+		calls:      make(map[*ast.CallExpr]interface{}),
+		evaluating: make(map[types.Object]bool),
+	}
+}
+
+// extract collects all errorx declarations from a package graph.
+func (x *extractor) extract(roots []*packages.Package) {
+	var all []*packages.Package
+	packages.Visit(roots, nil, func(pkg *packages.Package) {
+		if pkg.TypesInfo != nil {
+			all = append(all, pkg)
+		}
+	})
+	sort.Slice(all, func(i, j int) bool { return all[i].PkgPath < all[j].PkgPath })
+
+	for _, pkg := range all {
+		x.indexDeclarations(pkg)
+	}
+
+	for _, pkg := range all {
+		if !x.included(pkg) || !usesErrorx(pkg) {
+			continue
+		}
+
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.VAR {
+					x.evalVars(pkg, genDecl)
+				}
+			}
+
+			ast.Inspect(file, func(node ast.Node) bool {
+				if call, ok := node.(*ast.CallExpr); ok && isErrorxDeclaration(typeutil.StaticCallee(pkg.TypesInfo, call)) {
+					x.evalCall(pkg, call)
+				}
+				return true
+			})
+		}
+	}
+}
+
+func usesErrorx(pkg *packages.Package) bool {
+	if pkg.PkgPath == errorxPath {
+		return true
+	}
+
+	_, ok := pkg.Imports[errorxPath]
+	return ok
+}
+
+func (x *extractor) indexDeclarations(pkg *packages.Package) {
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if fn, ok := pkg.TypesInfo.Defs[decl.Name].(*types.Func); ok {
+					x.funcs[fn] = funcDecl{pkg: pkg, decl: decl}
+				}
+			case *ast.GenDecl:
+				if decl.Tok != token.VAR {
+					continue
+				}
+
+				for _, spec := range decl.Specs {
+					valueSpec := spec.(*ast.ValueSpec)
+					if len(valueSpec.Names) != len(valueSpec.Values) {
+						continue
+					}
+
+					for i, name := range valueSpec.Names {
+						if v, ok := pkg.TypesInfo.Defs[name].(*types.Var); ok {
+							x.vars[v] = varDecl{pkg: pkg, expr: valueSpec.Values[i]}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func (x *extractor) evalVars(pkg *packages.Package, decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		for _, name := range spec.(*ast.ValueSpec).Names {
+			if v, ok := pkg.TypesInfo.Defs[name].(*types.Var); ok {
+				x.evalObject(v)
+			}
+		}
+	}
+}
+
+func (x *extractor) eval(pkg *packages.Package, expr ast.Expr) interface{} {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return x.eval(pkg, expr.X)
+	case *ast.Ident:
+		return x.evalObject(pkg.TypesInfo.Uses[expr])
+	case *ast.SelectorExpr:
+		if _, ok := pkg.TypesInfo.Selections[expr]; ok {
+			// field or method value, not a qualified identifier
+			return nil
+		}
+		return x.evalObject(pkg.TypesInfo.Uses[expr.Sel])
+	case *ast.CallExpr:
+		return x.evalCall(pkg, expr)
+	default:
+		return nil
+	}
+}
+
+func (x *extractor) evalObject(obj types.Object) interface{} {
+	v, ok := obj.(*types.Var)
+	if !ok {
+		return nil
+	}
+
+	if value, ok := x.values[v]; ok {
+		return value
+	}
+
+	decl, ok := x.vars[v]
+	if !ok || x.evaluating[v] {
+		return nil
+	}
+
+	x.evaluating[v] = true
+	value := x.eval(decl.pkg, decl.expr)
+	delete(x.evaluating, v)
+
+	x.values[v] = value
+	if d := declarationOf(value); d != nil && d.goName == "" {
+		d.goName = v.Pkg().Name() + "." + v.Name()
+	}
+
+	return value
+}
+
+func (x *extractor) evalCall(pkg *packages.Package, call *ast.CallExpr) interface{} {
+	if value, ok := x.calls[call]; ok {
+		return value
+	}
+
+	fn := typeutil.StaticCallee(pkg.TypesInfo, call)
+	if fn == nil {
+		return nil
+	}
+
+	var value interface{}
+	if isErrorxDeclaration(fn) {
+		value = x.evalDeclaration(pkg, call, fn)
+	} else {
+		value = x.evalFuncResult(fn)
+	}
+
+	x.calls[call] = value
+	return value
+}
+
+// evalFuncResult follows a function that merely returns a value, such as errorx.Timeout()
+func (x *extractor) evalFuncResult(fn *types.Func) interface{} {
+	decl, ok := x.funcs[fn]
+	if !ok || decl.decl.Body == nil || len(decl.decl.Body.List) != 1 || x.evaluating[fn] {
+		return nil
+	}
+
+	ret, ok := decl.decl.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil
+	}
+
+	x.evaluating[fn] = true
+	defer delete(x.evaluating, fn)
+
+	return x.eval(decl.pkg, ret.Results[0])
+}
+
+func isErrorxDeclaration(fn *types.Func) bool {
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != errorxPath {
+		return false
+	}
+
+	switch receiverName(fn) + "." + fn.Name() {
+	case ".NewNamespace", "Namespace.NewSubNamespace", ".NewType", "Namespace.NewType", "Type.NewSubtype",
+		"Namespace.ApplyModifiers", "Type.ApplyModifiers", "Type.WithCode",
+		".RegisterTrait", ".Implies", ".RegisterTypeModifier", ".RegisterProperty", ".RegisterPrintableProperty":
+		return true
+	default:
+		return false
+	}
+}
+
+func receiverName(fn *types.Func) string {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return ""
+	}
+
+	t := recv.Type()
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj().Name()
+	}
+
+	return ""
+}
+
+func (x *extractor) evalDeclaration(pkg *packages.Package, call *ast.CallExpr, fn *types.Func) interface{} {
+	var receiver interface{}
+	if selector, ok := call.Fun.(*ast.SelectorExpr); ok && receiverName(fn) != "" {
+		receiver = x.eval(pkg, selector.X)
+	}
+
+	d := x.declaration(pkg, call)
+
+	switch receiverName(fn) + "." + fn.Name() {
+	case ".NewNamespace":
+		return x.newNamespace(pkg, nil, call.Args, d)
+	case "Namespace.NewSubNamespace":
+		if parent, ok := receiver.(*namespaceEntry); ok {
+			return x.newNamespace(pkg, parent, call.Args, d)
+		}
+	case ".NewType":
+		if len(call.Args) > 0 {
+			if namespace, ok := x.eval(pkg, call.Args[0]).(*namespaceEntry); ok {
+				return x.newType(pkg, namespace, nil, call.Args[1:], d)
+			}
+		}
+	case "Namespace.NewType":
+		if namespace, ok := receiver.(*namespaceEntry); ok {
+			return x.newType(pkg, namespace, nil, call.Args, d)
+		}
+	case "Type.NewSubtype":
+		if parent, ok := receiver.(*typeEntry); ok {
+			return x.newType(pkg, parent.namespace, parent, call.Args, d)
+		}
+	case "Namespace.ApplyModifiers":
+		if namespace, ok := receiver.(*namespaceEntry); ok {
+			namespace.modifiers = append(namespace.modifiers, x.modifiers(pkg, call.Args, "TypeModifier")...)
+			return namespace
+		}
+	case "Type.ApplyModifiers":
+		if t, ok := receiver.(*typeEntry); ok {
+			t.modifiers = append(t.modifiers, x.modifiers(pkg, call.Args, "TypeModifier")...)
+			return t
+		}
+	case "Type.WithCode":
+		if t, ok := receiver.(*typeEntry); ok {
+			if code, ok := constantString(pkg, call.Args, 0); ok {
+				t.code = code
+			}
+			return t
+		}
+	case ".RegisterTrait":
+		if label, ok := constantString(pkg, call.Args, 0); ok {
+			trait := &traitEntry{label: label, declaration: d}
+			for _, arg := range call.Args[1:] {
+				if implies, ok := x.eval(pkg, arg).(*impliesEntry); ok {
+					trait.implies = appendTraits(trait.implies, implies.traits...)
+				}
+			}
+			x.traits = append(x.traits, trait)
+			return trait
+		}
+	case ".Implies":
+		return &impliesEntry{traits: x.traitArgs(pkg, call.Args)}
+	case ".RegisterTypeModifier":
+		if name, ok := constantString(pkg, call.Args, 0); ok {
+			return &modifierEntry{name: name}
+		}
+	case ".RegisterProperty", ".RegisterPrintableProperty":
+		if label, ok := constantString(pkg, call.Args, 0); ok {
+			property := &propertyEntry{
+				label:       label,
+				printable:   fn.Name() == "RegisterPrintableProperty",
+				modifiers:   x.modifiers(pkg, call.Args[1:], "PropertyModifier"),
+				declaration: d,
+			}
+			x.properties = append(x.properties, property)
+			return property
+		}
+	}
+
+	return nil
+}
+
+func (x *extractor) newNamespace(pkg *packages.Package, parent *namespaceEntry, args []ast.Expr, d declaration) interface{} {
+	name, ok := constantString(pkg, args, 0)
+	if !ok {
+		return nil
+	}
+
+	if parent != nil {
+		name = parent.name + "." + name
+	}
+
+	namespace := &namespaceEntry{name: name, parent: parent, traits: x.traitArgs(pkg, args[1:]), declaration: d}
+	x.namespaces = append(x.namespaces, namespace)
+	return namespace
+}
+
+func (x *extractor) newType(pkg *packages.Package, namespace *namespaceEntry, parent *typeEntry, args []ast.Expr, d declaration) interface{} {
+	name, ok := constantString(pkg, args, 0)
+	if !ok {
+		return nil
+	}
+
+	if parent != nil {
+		name = parent.name + "." + name
+	} else {
+		name = namespace.name + "." + name
+	}
+
+	t := &typeEntry{name: name, namespace: namespace, parent: parent, traits: x.traitArgs(pkg, args[1:]), declaration: d}
+	x.types = append(x.types, t)
+	return t
+}
+
+func (x *extractor) traitArgs(pkg *packages.Package, args []ast.Expr) []*traitEntry {
+	var result []*traitEntry
+	for _, arg := range args {
+		if trait, ok := x.eval(pkg, arg).(*traitEntry); ok {
+			result = append(result, trait)
+		}
+	}
+	return result
+}
+
+// modifiers lists the names of errorx modifier constants without a prefix, such as "TypeModifier",
+// and the names of registered type modifiers
+func (x *extractor) modifiers(pkg *packages.Package, args []ast.Expr, prefix string) []string {
+	var result []string
+	for _, arg := range args {
+		if modifier, ok := x.eval(pkg, arg).(*modifierEntry); ok {
+			result = append(result, modifier.name)
+			continue
+		}
+
+		var ident *ast.Ident
+		switch arg := arg.(type) {
+		case *ast.Ident:
+			ident = arg
+		case *ast.SelectorExpr:
+			ident = arg.Sel
+		}
+
+		if ident != nil {
+			if c, ok := pkg.TypesInfo.Uses[ident].(*types.Const); ok && c.Pkg().Path() == errorxPath {
+				result = append(result, strings.TrimPrefix(c.Name(), prefix))
+				continue
+			}
+		}
+
+		if value := pkg.TypesInfo.Types[arg].Value; value != nil {
+			result = append(result, "Modifier"+value.ExactString())
+		}
+	}
+	return result
+}
+
+func (x *extractor) declaration(pkg *packages.Package, node ast.Node) declaration {
+	position := x.fset.Position(node.Pos())
+	file := position.Filename
+	if i := strings.LastIndexAny(file, `/\`); i >= 0 {
+		file = file[i+1:]
+	}
+
+	return declaration{
+		file:     pkg.PkgPath + "/" + file,
+		line:     position.Line,
+		column:   position.Column,
+		included: x.included(pkg),
+	}
+}
+
+func constantString(pkg *packages.Package, args []ast.Expr, index int) (string, bool) {
+	if index >= len(args) {
+		return "", false
+	}
+
+	value := pkg.TypesInfo.Types[args[index]].Value
+	if value == nil || value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(value), true
+}
+
+func declarationOf(value interface{}) *declaration {
+	switch value := value.(type) {
+	case *namespaceEntry:
+		return &value.declaration
+	case *typeEntry:
+		return &value.declaration
+	case *traitEntry:
+		return &value.declaration
+	case *propertyEntry:
+		return &value.declaration
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,19 @@
+package errs
+
+import "github.com/joomcode/errorx"
+
+var (
+	Retryable = errorx.RegisterTrait("retryable", errorx.Implies(errorx.Temporary()))
+	Attempt   = errorx.RegisterPrintableProperty("attempt")
+	Token     = errorx.RegisterProperty("token", errorx.PropertyModifierSensitive)
+
+	Storage  = errorx.NewNamespace("storage", Retryable)
+	Cache    = Storage.NewSubNamespace("cache")
+	Conflict = Storage.NewType("conflict", errorx.Duplicate()).WithCode("S409")
+	Stale    = Conflict.NewSubtype("stale").ApplyModifiers(errorx.TypeModifierOmitStackTrace)
+	Miss     = Cache.NewType("miss").ApplyModifiers(Audited)
+
+	Timeout = errorx.TimeoutElapsed.NewSubtype("storage_timeout")
+
+	Audited = errorx.RegisterTypeModifier("Audited")
+)
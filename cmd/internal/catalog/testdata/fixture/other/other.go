@@ -0,0 +1,5 @@
+package other
+
+import "github.com/joomcode/errorx"
+
+var Ignored = errorx.NewNamespace("ignored")
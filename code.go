@@ -0,0 +1,104 @@
+package errorx
+
+import "sync/atomic"
+
+// WithCode assigns a stable code to this type, which is inherited by all its subtypes unless those are assigned their own.
+// Unlike a full name, a code survives the renaming of a type or a namespace, and may therefore be exposed to clients.
+// Any non-empty string may be used as a code, a number in decimal form included.
+//
+// Codes are unique within a registry: an attempt to assign a code which is already taken by another type causes panic,
+// as does an attempt to change a code once assigned.
+func (t *Type) WithCode(code string) *Type {
+	t.namespace.registry().assignCode(t, code)
+	return t
+}
+
+// Code returns a code assigned to this type or inherited from the closest supertype, see WithCode.
+// The result is empty if there is none.
+func (t *Type) Code() string {
+	for current := t; current != nil; current = current.parent {
+		if current.code != "" {
+			return current.code
+		}
+	}
+
+	return ""
+}
+
+// Code returns a code of the type of this error, see Type.WithCode.
+// As with Type(), a transparent wrapper reports a code of the original cause.
+func (e *Error) Code() string {
+	return e.Type().Code()
+}
+
+// ErrorCode returns a code of the type of an error, see Type.WithCode.
+// The result is empty for an error without a code, non-errorx errors included.
+func ErrorCode(err error) string {
+	if typedErr := Cast(err); typedErr != nil {
+		return typedErr.Code()
+	}
+
+	return ""
+}
+
+// LookupTypeByCode finds an error type by a code assigned to it.
+// Only the type the code was assigned to is found, not its subtypes which inherit it.
+func LookupTypeByCode(code string) (*Type, bool) {
+	return globalRegistry.LookupTypeByCode(code)
+}
+
+// IncludeCodesInMessages switches the output of codes in error messages, which is off by default.
+// With codes included, an error of a type with a code is printed as 'namespace.type [code]: message',
+// both in Error() and in formatted output.
+// Returns the previous setting.
+func IncludeCodesInMessages(include bool) bool {
+	value := int32(0)
+	if include {
+		value = 1
+	}
+
+	return atomic.SwapInt32(&codesInMessages, value) != 0
+}
+
+var codesInMessages int32
+
+func includeCodesInMessages() bool {
+	return atomic.LoadInt32(&codesInMessages) != 0
+}
+
+// LookupTypeByCode finds an error type in this registry by a code assigned to it, see errorx.LookupTypeByCode.
+func (r *Registry) LookupTypeByCode(code string) (*Type, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.codes[code]
+	return t, ok
+}
+
+func (r *Registry) assignCode(t *Type, code string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch other, taken := r.codes[code]; {
+	case code == "":
+		panic("empty code for type '" + t.FullName() + "'")
+	case t.code == code:
+		return
+	case t.code != "":
+		panic("type '" + t.FullName() + "' is already assigned code '" + t.code + "'")
+	case taken:
+		panic("duplicate code: '" + code + "' is already assigned to type '" + other.FullName() + "'")
+	}
+
+	if r.codes == nil {
+		r.codes = make(map[string]*Type)
+	}
+	r.codes[code] = t
+	t.code = code
+
+	for _, s := range r.subscribers {
+		if ms, ok := s.subscriber.(ModifierSubscriber); ok {
+			ms.OnTypeModified(t)
+		}
+	}
+}
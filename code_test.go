@@ -0,0 +1,90 @@
+package errorx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	codeTestNamespace = NewNamespace("code")
+	codeTestType      = codeTestNamespace.NewType("type").WithCode("CODE-1")
+	codeTestSubtype   = codeTestType.NewSubtype("subtype")
+	codeTestOverride  = codeTestType.NewSubtype("override").WithCode("CODE-2")
+	codeTestNoCode    = codeTestNamespace.NewType("no_code")
+)
+
+func TestCode(t *testing.T) {
+	t.Run("Inheritance", func(t *testing.T) {
+		require.Equal(t, "CODE-1", codeTestType.Code())
+		require.Equal(t, "CODE-1", codeTestSubtype.Code())
+		require.Equal(t, "CODE-2", codeTestOverride.Code())
+		require.Equal(t, "", codeTestNoCode.Code())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		require.Equal(t, "CODE-1", codeTestSubtype.New("test").Code())
+		require.Equal(t, "CODE-2", ErrorCode(Decorate(codeTestOverride.New("test"), "decorated")))
+		require.Equal(t, "", ErrorCode(codeTestNoCode.New("test")))
+		require.Equal(t, "", ErrorCode(fmt.Errorf("foreign")))
+	})
+
+	t.Run("Lookup", func(t *testing.T) {
+		found, ok := LookupTypeByCode("CODE-1")
+		require.True(t, ok)
+		require.Equal(t, codeTestType, found)
+
+		found, ok = LookupTypeByCode("CODE-2")
+		require.True(t, ok)
+		require.Equal(t, codeTestOverride, found)
+
+		_, ok = LookupTypeByCode("CODE-3")
+		require.False(t, ok)
+	})
+
+	t.Run("Uniqueness", func(t *testing.T) {
+		r := NewRegistry()
+		namespace := r.NewNamespace("code")
+		first := namespace.NewType("first").WithCode("1")
+		second := namespace.NewType("second")
+
+		require.Panics(t, func() { second.WithCode("1") })
+		require.Panics(t, func() { first.WithCode("2") })
+		require.Panics(t, func() { second.WithCode("") })
+		require.NotPanics(t, func() { first.WithCode("1") })
+		require.Equal(t, "", second.Code())
+
+		second.WithCode("2")
+		found, ok := r.LookupTypeByCode("2")
+		require.True(t, ok)
+		require.Equal(t, second, found)
+
+		_, ok = LookupTypeByCode("2")
+		require.False(t, ok)
+
+		other := NewRegistry().NewNamespace("code").NewType("first")
+		require.NotPanics(t, func() { other.WithCode("1") })
+	})
+
+	t.Run("Subscriber", func(t *testing.T) {
+		r := NewRegistry()
+		s := &testModifierSubscriber{}
+		r.RegisterTypeSubscriber(s)
+
+		errorType := r.NewNamespace("code").NewType("type").WithCode("1")
+		require.Equal(t, []*Type{errorType}, s.modifiedTypes)
+	})
+}
+
+func TestCodeInMessage(t *testing.T) {
+	err := Decorate(codeTestSubtype.Wrap(codeTestNoCode.New("cause"), "wrapped"), "decorated")
+	require.Equal(t, "decorated, cause: code.type.subtype: wrapped, cause: code.no_code: cause", err.Error())
+
+	previous := IncludeCodesInMessages(true)
+	defer IncludeCodesInMessages(previous)
+
+	require.Equal(t, "decorated, cause: code.type.subtype [CODE-1]: wrapped, cause: code.no_code: cause", err.Error())
+	require.Equal(t, "code.type.subtype [CODE-1]: wrapped, cause: code.no_code: cause", fmt.Sprintf("%v", err.Cause()))
+	require.True(t, IncludeCodesInMessages(true))
+}
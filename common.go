@@ -36,4 +36,8 @@ var (
 	NotImplemented = UnsupportedOperation.NewSubtype("not_implemented")
 	// UnsupportedVersion is a type for unsupported version error
 	UnsupportedVersion = UnsupportedOperation.NewSubtype("version")
+	// RecoveredPanic is a type for an error recovered from panic, see Recover() and Try()
+	RecoveredPanic = InternalError.NewSubtype("panic")
+	// RuntimePanic is a type for an error recovered from a runtime fault, such as nil dereference or index out of range
+	RuntimePanic = RecoveredPanic.NewSubtype("runtime", RuntimeFault())
 )
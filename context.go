@@ -0,0 +1,90 @@
+package errorx
+
+import (
+	"context"
+	"sync"
+)
+
+// ContextExtractor reads a value of a property from a context, if the context has one.
+type ContextExtractor func(ctx context.Context) (interface{}, bool)
+
+// RegisterContextProperty provides a way to fill a property from a context for all the errors created with one,
+// see Type.NewCtx, Type.WrapCtx, DecorateCtx and ErrorBuilder.WithContextProperties.
+// An extractor is expected to be registered once, typically alongside the property itself, as in:
+//
+//	var PropertyRequestID = errorx.RegisterPrintableProperty("request_id", errorx.PropertyModifierSticky)
+//
+//	func init() {
+//		errorx.RegisterContextProperty(PropertyRequestID, func(ctx context.Context) (interface{}, bool) {
+//			id, ok := ctx.Value(requestIDKey{}).(string)
+//			return id, ok
+//		})
+//	}
+//
+// If several extractors are registered for the same property, the last one that finds a value wins.
+// Unlike with errorx.WithContext, the context itself is not retained by an error.
+func RegisterContextProperty(p Property, extract ContextExtractor) {
+	contextProperties.mu.Lock()
+	defer contextProperties.mu.Unlock()
+
+	contextProperties.extractors = append(contextProperties.extractors, contextExtractor{p: p, extract: extract})
+}
+
+// NewCtx creates an error of this type with a message, as New does, along with the properties extracted from a context.
+func (t *Type) NewCtx(ctx context.Context, message string, args ...interface{}) *Error {
+	return NewErrorBuilder(t).
+		WithConditionallyFormattedMessage(message, args...).
+		WithContextProperties(ctx).
+		Create()
+}
+
+// WrapCtx creates an error of this type with another as original cause, as Wrap does, along with the properties extracted from a context.
+func (t *Type) WrapCtx(ctx context.Context, err error, message string, args ...interface{}) *Error {
+	return NewErrorBuilder(t).
+		WithConditionallyFormattedMessage(message, args...).
+		WithCause(err).
+		WithContextProperties(ctx).
+		Create()
+}
+
+// DecorateCtx decorates an error with a message, as Decorate does, along with the properties extracted from a context.
+func DecorateCtx(ctx context.Context, err error, message string, args ...interface{}) *Error {
+	return NewErrorBuilder(transparentWrapper).
+		WithConditionallyFormattedMessage(message, args...).
+		WithCause(err).
+		WithContextProperties(ctx).
+		Create()
+}
+
+// WithContextProperties provides a context to extract the properties of an error from, see RegisterContextProperty.
+// Properties set explicitly, such as by a message template, take precedence over those extracted.
+func (eb ErrorBuilder) WithContextProperties(ctx context.Context) ErrorBuilder {
+	eb.ctx = ctx
+	return eb
+}
+
+// withContextProperties sets the properties extracted from a context to an error being created
+func (e *Error) withContextProperties(ctx context.Context) *Error {
+	contextProperties.mu.RLock()
+	defer contextProperties.mu.RUnlock()
+
+	for _, extractor := range contextProperties.extractors {
+		if value, ok := extractor.extract(ctx); ok {
+			e = e.WithProperty(extractor.p, value)
+		}
+	}
+
+	return e
+}
+
+type contextExtractor struct {
+	p       Property
+	extract ContextExtractor
+}
+
+var contextProperties = struct {
+	mu         *sync.RWMutex
+	extractors []contextExtractor
+}{
+	mu: &sync.RWMutex{},
+}
@@ -0,0 +1,97 @@
+package errorx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type contextTestKey struct{}
+
+var (
+	contextTestRequest = RegisterPrintableProperty("context_request")
+	contextTestUser    = RegisterProperty("context_user")
+)
+
+func init() {
+	RegisterContextProperty(contextTestRequest, func(ctx context.Context) (interface{}, bool) {
+		value, ok := ctx.Value(contextTestKey{}).(string)
+		return value, ok
+	})
+}
+
+func TestContextProperty(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextTestKey{}, "req-1")
+
+	t.Run("NewCtx", func(t *testing.T) {
+		err := testType.NewCtx(ctx, "test %d", 1)
+		require.Equal(t, "foo.bar: test 1 {context_request: req-1}", err.Error())
+
+		value, ok := err.Property(contextTestRequest)
+		require.True(t, ok)
+		require.Equal(t, "req-1", value)
+
+		_, ok = err.Property(PropertyContext())
+		require.False(t, ok)
+	})
+
+	t.Run("WrapCtx", func(t *testing.T) {
+		err := testType.WrapCtx(ctx, errors.New("boom"), "test")
+		require.True(t, err.IsOfType(testType))
+		require.Equal(t, "foo.bar: test {context_request: req-1}, cause: boom", err.Error())
+	})
+
+	t.Run("DecorateCtx", func(t *testing.T) {
+		err := DecorateCtx(ctx, testTypeBar1.New("test"), "decorated")
+		require.True(t, err.IsOfType(testTypeBar1))
+		value, ok := err.Property(contextTestRequest)
+		require.True(t, ok)
+		require.Equal(t, "req-1", value)
+	})
+
+	t.Run("Builder", func(t *testing.T) {
+		template := NewMessageTemplate("request {context_request}", contextTestRequest)
+		err := NewErrorBuilder(testType).WithContextProperties(ctx).WithMessageTemplate(template, "explicit").Create()
+		value, ok := err.Property(contextTestRequest)
+		require.True(t, ok)
+		require.Equal(t, "explicit", value)
+	})
+
+	t.Run("NoValue", func(t *testing.T) {
+		err := testType.NewCtx(context.Background(), "test")
+		_, ok := err.Property(contextTestRequest)
+		require.False(t, ok)
+		require.Equal(t, "foo.bar: test", err.Error())
+	})
+
+	t.Run("LastWins", func(t *testing.T) {
+		RegisterContextProperty(contextTestUser, func(ctx context.Context) (interface{}, bool) {
+			return "first", true
+		})
+		RegisterContextProperty(contextTestUser, func(ctx context.Context) (interface{}, bool) {
+			return nil, false
+		})
+		RegisterContextProperty(contextTestUser, func(ctx context.Context) (interface{}, bool) {
+			return "last", true
+		})
+
+		value, ok := testType.NewCtx(ctx, "test").Property(contextTestUser)
+		require.True(t, ok)
+		require.Equal(t, "last", value)
+	})
+
+	t.Run("StackTrace", func(t *testing.T) {
+		for name, err := range map[string]*Error{
+			"NewCtx()":      testType.NewCtx(ctx, "test"),
+			"WrapCtx()":     testType.WrapCtx(ctx, errors.New("boom"), "test"),
+			"DecorateCtx()": DecorateCtx(ctx, errors.New("boom"), "test"),
+		} {
+			output := fmt.Sprintf("%+v", err)
+			require.NotContains(t, output, name, output)
+			require.Contains(t, output, "TestContextProperty", output)
+		}
+	})
+}
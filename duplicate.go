@@ -0,0 +1,156 @@
+package errorx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DuplicateName describes a full name shared by a number of registered entities of the same kind.
+type DuplicateName struct {
+	// Kind is a kind of entities that share the name: "namespace", "type" or "trait"
+	Kind string
+	// Name is a full name of a namespace or a type, or a label of a trait
+	Name string
+	// Count is a number of entities registered with this name
+	Count int
+}
+
+func (d DuplicateName) String() string {
+	return fmt.Sprintf("%s '%s' is registered %d times", d.Kind, d.Name, d.Count)
+}
+
+// FindDuplicateNames reports the names shared by a number of registered namespaces, types or traits.
+// The result is empty if all the names are unique, which is a prerequisite for name-based logging, metrics or decoding.
+// See EnableStrictNames for a way to forbid duplicates altogether.
+func FindDuplicateNames() []DuplicateName {
+	return globalRegistry.FindDuplicateNames()
+}
+
+// CheckUniqueNames returns an error listing all duplicates reported by FindDuplicateNames, or nil if there are none.
+func CheckUniqueNames() error {
+	return globalRegistry.CheckUniqueNames()
+}
+
+// EnableStrictNames switches the registry into the strict mode, which is off by default.
+// In the strict mode, a registration of a namespace, a type or a trait with a name that is already taken causes panic.
+// Duplicates registered before the call cause panic at the moment of the call.
+//
+// As errors are typically declared in package variables, the strict mode is only effective for packages initialized later.
+// Use FindDuplicateNames or CheckUniqueNames to verify the names registered before the switch.
+func EnableStrictNames() {
+	globalRegistry.EnableStrictNames()
+}
+
+const (
+	duplicateKindNamespace = "namespace"
+	duplicateKindType      = "type"
+	duplicateKindTrait     = "trait"
+)
+
+// EnableStrictNames switches this registry into the strict mode, see errorx.EnableStrictNames.
+func (r *Registry) EnableStrictNames() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if duplicates := r.findDuplicateNamesLocked(); len(duplicates) > 0 {
+		panic("duplicate name: " + duplicates[0].String())
+	}
+
+	r.strictNames = true
+}
+
+// CheckUniqueNames returns an error listing all duplicates in this registry, see errorx.CheckUniqueNames.
+func (r *Registry) CheckUniqueNames() error {
+	duplicates := r.FindDuplicateNames()
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(duplicates))
+	for _, d := range duplicates {
+		descriptions = append(descriptions, d.String())
+	}
+
+	return IllegalState.New("duplicate names: %s", strings.Join(descriptions, ", "))
+}
+
+// FindDuplicateNames reports the names shared by a number of entities in this registry, see errorx.FindDuplicateNames.
+func (r *Registry) FindDuplicateNames() []DuplicateName {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.findDuplicateNamesLocked()
+}
+
+func (r *Registry) findDuplicateNamesLocked() []DuplicateName {
+	var result []DuplicateName
+	collect := func(kind string, names []string) {
+		counts := make(map[string]int, len(names))
+		for _, name := range names {
+			counts[name]++
+		}
+
+		for _, name := range names {
+			if count := counts[name]; count > 1 {
+				result = append(result, DuplicateName{Kind: kind, Name: name, Count: count})
+				delete(counts, name)
+			}
+		}
+	}
+
+	namespaceNames := make([]string, 0, len(r.knownNamespaces))
+	for _, namespace := range r.knownNamespaces {
+		namespaceNames = append(namespaceNames, namespace.FullName())
+	}
+	collect(duplicateKindNamespace, namespaceNames)
+
+	typeNames := make([]string, 0, len(r.knownTypes))
+	for _, t := range r.knownTypes {
+		typeNames = append(typeNames, t.FullName())
+	}
+	collect(duplicateKindType, typeNames)
+
+	traitNames := make([]string, 0, len(r.knownTraits))
+	for _, trait := range r.knownTraits {
+		traitNames = append(traitNames, trait.Label())
+	}
+	collect(duplicateKindTrait, traitNames)
+
+	return result
+}
+
+func (r *Registry) checkNamespaceNameLocked(namespace Namespace) {
+	if !r.strictNames {
+		return
+	}
+
+	for _, known := range r.knownNamespaces {
+		if known.FullName() == namespace.FullName() {
+			panic("duplicate name: " + duplicateKindNamespace + " '" + namespace.FullName() + "' is already registered")
+		}
+	}
+}
+
+func (r *Registry) checkTypeNameLocked(t *Type) {
+	if !r.strictNames {
+		return
+	}
+
+	for _, known := range r.knownTypes {
+		if known.FullName() == t.FullName() {
+			panic("duplicate name: " + duplicateKindType + " '" + t.FullName() + "' is already registered")
+		}
+	}
+}
+
+func (r *Registry) checkTraitNameLocked(trait Trait) {
+	if !r.strictNames {
+		return
+	}
+
+	for _, known := range r.knownTraits {
+		if known.Label() == trait.Label() {
+			panic("duplicate name: " + duplicateKindTrait + " '" + trait.Label() + "' is already registered")
+		}
+	}
+}
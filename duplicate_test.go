@@ -0,0 +1,80 @@
+package errorx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicateNames(t *testing.T) {
+	t.Run("Unique", func(t *testing.T) {
+		r := NewRegistry()
+		r.registerNamespace(Namespace{name: "unique"})
+		r.registerType(&Type{fullName: "unique.type"})
+		r.registerTrait(Trait{label: "unique"})
+
+		require.Empty(t, r.FindDuplicateNames())
+		require.NoError(t, r.CheckUniqueNames())
+	})
+
+	t.Run("Duplicates", func(t *testing.T) {
+		r := NewRegistry()
+		r.registerNamespace(Namespace{name: "duplicate"})
+		r.registerNamespace(Namespace{name: "duplicate"})
+		r.registerNamespace(Namespace{name: "unique"})
+		r.registerType(&Type{fullName: "duplicate.type"})
+		r.registerType(&Type{fullName: "duplicate.type"})
+		r.registerType(&Type{fullName: "duplicate.type"})
+		r.registerTrait(Trait{label: "duplicate"})
+		r.registerTrait(Trait{label: "duplicate"})
+
+		require.Equal(t, []DuplicateName{
+			{Kind: "namespace", Name: "duplicate", Count: 2},
+			{Kind: "type", Name: "duplicate.type", Count: 3},
+			{Kind: "trait", Name: "duplicate", Count: 2},
+		}, r.FindDuplicateNames())
+
+		err := r.CheckUniqueNames()
+		require.True(t, IsOfType(err, IllegalState))
+		require.Contains(t, err.Error(), "type 'duplicate.type' is registered 3 times")
+	})
+}
+
+func TestStrictNames(t *testing.T) {
+	t.Run("Namespace", func(t *testing.T) {
+		r := NewRegistry()
+		r.EnableStrictNames()
+		r.registerNamespace(Namespace{name: "strict"})
+		require.Panics(t, func() {
+			r.registerNamespace(Namespace{name: "strict"})
+		})
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		r := NewRegistry()
+		r.EnableStrictNames()
+		r.registerType(&Type{fullName: "strict.type"})
+		r.registerType(&Type{fullName: "strict.other"})
+		require.Panics(t, func() {
+			r.registerType(&Type{fullName: "strict.type"})
+		})
+	})
+
+	t.Run("Trait", func(t *testing.T) {
+		r := NewRegistry()
+		r.EnableStrictNames()
+		r.registerTrait(Trait{label: "strict"})
+		require.Panics(t, func() {
+			r.registerTrait(Trait{label: "strict"})
+		})
+	})
+
+	t.Run("ExistingDuplicates", func(t *testing.T) {
+		r := NewRegistry()
+		r.registerTrait(Trait{label: "strict"})
+		r.registerTrait(Trait{label: "strict"})
+		require.Panics(t, func() {
+			r.EnableStrictNames()
+		})
+	})
+}
@@ -15,12 +15,15 @@ type Error struct {
 	cause      error
 	stackTrace *stackTrace
 	// properties are used both for public properties inherited through "transparent" wrapping
-	// and for some optional per-instance information like "underlying errors"
+	// and for some optional per-instance information like "underlying errors" and traits
 	properties *propertyMap
 
 	transparent            bool
 	hasUnderlying          bool
+	hasTraits              bool // either added or masked
 	printablePropertyCount uint8
+	// inheritedPropertyCount is a number of properties visible through a transparent wrap, see visiblePropertyCount
+	inheritedPropertyCount uint32
 }
 
 var _ fmt.Formatter = (*Error)(nil)
@@ -29,7 +32,8 @@ var _ fmt.Formatter = (*Error)(nil)
 // If an error already contained another value for the same property, it is overwritten.
 // It is a caller's responsibility to accumulate and update a property, if needed.
 // Dynamic properties is a brittle mechanism and should therefore be used with care and in a simple and robust manner.
-// Currently, properties are implemented as a linked list, therefore it is not safe to have many dozens of them. But couple of dozen is just ok.
+// Adding a property copies none of the existing ones, while an error with dozens of them is indexed upon the first lookup,
+// so that lookups remain fast.
 func (e *Error) WithProperty(key Property, value interface{}) *Error {
 	errorCopy := *e
 	errorCopy.properties = errorCopy.properties.with(key, value)
@@ -39,6 +43,25 @@ func (e *Error) WithProperty(key Property, value interface{}) *Error {
 	return &errorCopy
 }
 
+// WithTrait adds traits to error instance, in addition to those of its type.
+// Such traits are visible to HasTrait and TraitSwitch as properties are visible with Property():
+// those of the cause are possessed through a transparent wrap, such as by Decorate, but not through an opaque one.
+// This way, an operation that may fail both in a temporary and in a permanent way needs no separate type for each.
+// The traits implied by those added are possessed as well, see Implies.
+func (e *Error) WithTrait(traits ...Trait) *Error {
+	instanceTraits := append([]Trait(nil), e.instanceTraits()...)
+	for _, trait := range traits {
+		instanceTraits = appendTrait(instanceTraits, trait)
+		for _, implied := range trait.Implied() {
+			instanceTraits = appendTrait(instanceTraits, implied)
+		}
+	}
+
+	errorCopy := e.WithProperty(propertyTraits, instanceTraits)
+	errorCopy.hasTraits = true
+	return errorCopy
+}
+
 // WithUnderlyingErrors adds multiple additional related (hidden, suppressed) errors to be used exclusively in error output.
 // Note that these errors make no other effect whatsoever: their traits, types, properties etc. are lost on the observer.
 // Consider using errorx.DecorateMany instead.
@@ -67,16 +90,22 @@ func (e *Error) WithUnderlyingErrors(errs ...error) *Error {
 // Property extracts a dynamic property value from an error.
 // A property may belong to this error or be extracted from the original cause.
 // The transparency rules are respected to some extent: both the original cause and the transparent wrapper
-// may have accessible properties, but an opaque wrapper hides the original properties.
+// may have accessible properties, but an opaque wrapper hides the original properties, except for sticky ones, see PropertyModifierSticky.
 func (e *Error) Property(key Property) (interface{}, bool) {
 	cause := e
 	for cause != nil {
-		value, ok := cause.properties.get(key)
-		if ok {
-			return value, true
+		// the check is done here, so that a linear scan for a few properties is inlined
+		if pm := cause.properties; pm != nil {
+			if pm.count+int(cause.inheritedPropertyCount) >= propertyIndexThreshold {
+				// an index holds the properties of the causes as well, as far as those are visible
+				return pm.lookup(cause, key)
+			}
+			if value, ok := pm.get(key); ok {
+				return value, true
+			}
 		}
 
-		if !cause.transparent {
+		if !cause.transparent && !key.sticky {
 			break
 		}
 
@@ -86,18 +115,69 @@ func (e *Error) Property(key Property) (interface{}, bool) {
 	return nil, false
 }
 
+// VisitProperties calls a visitor for each property of an error with its value, until the visitor returns false.
+// Properties are visible as with Property(): those of the cause are included only through a transparent wrap unless sticky,
+// and a property set more than once is visited with the value Property() would return, that is, the one set last or closest to the error.
+// Properties for internal use are skipped, and the values of sensitive properties are redacted, see PropertyModifierSensitive.
+// Properties are visited from those set last, layer by layer.
+func (e *Error) VisitProperties(visitor func(p Property, value interface{}) bool) {
+	visited := make(map[Property]struct{})
+	opaque := false
+	for cause := e; cause != nil; cause = Cast(cause.Cause()) {
+		for m := cause.properties; m != nil; m = m.next {
+			if m.p.internal || (opaque && !m.p.sticky) {
+				continue
+			}
+			if _, ok := visited[m.p]; ok {
+				continue
+			}
+
+			visited[m.p] = struct{}{}
+			if !visitor(m.p, redactedValue(m.p, m.value)) {
+				return
+			}
+		}
+
+		if !cause.transparent {
+			opaque = true
+		}
+	}
+}
+
+// Properties returns all the properties of an error with their values, see VisitProperties.
+func (e *Error) Properties() map[Property]interface{} {
+	properties := make(map[Property]interface{})
+	e.VisitProperties(func(p Property, value interface{}) bool {
+		properties[p] = value
+		return true
+	})
+
+	return properties
+}
+
 // HasTrait checks if an error possesses the expected trait.
 // Trait check works just as a type check would: opaque wrap hides the traits of the cause.
-// Traits are always properties of a type rather than of an instance, so trait check is an alternative to a type check.
+// Traits are mostly properties of a type rather than of an instance, so trait check is an alternative to a type check.
 // This alternative is preferable, though, as it is less brittle and generally creates less of a dependency.
+// The traits added to an error instance are checked along with those of its type, see WithTrait,
+// and the traits masked by a transparent wrapper are hidden regardless of the cause, see MaskTraits.
+// A foreign, non-errorx cause of a transparent wrap is checked against the classifiers, see RegisterTraitClassifier.
 func (e *Error) HasTrait(key Trait) bool {
 	cause := e
 	for cause != nil {
+		if possessed, ok := cause.instanceTrait(key); ok {
+			return possessed
+		}
+
 		if !cause.transparent {
 			return cause.errorType.HasTrait(key)
 		}
 
-		cause = Cast(cause.Cause())
+		next := Cast(cause.Cause())
+		if next == nil && cause.Cause() != nil {
+			return classifyTrait(cause.Cause(), key)
+		}
+		cause = next
 	}
 
 	return false
@@ -107,12 +187,14 @@ func (e *Error) HasTrait(key Trait) bool {
 // It takes the transparency and error types hierarchy into account,
 // so that type check against any supertype of the original cause passes.
 // Go 1.13 and above: it also tolerates non-errorx errors in chain if those errors support errors unwrap.
+// A foreign, non-errorx cause of a transparent wrap is checked against the classifiers, see RegisterTypeClassifier.
 func (e *Error) IsOfType(t *Type) bool {
 	return e.isOfType(t)
 }
 
 // Type returns the exact type of this error.
 // With transparent wrapping, such as in Decorate(), returns the type of the original cause.
+// A foreign, non-errorx cause of a transparent wrap has a type it is classified into, see RegisterTypeClassifier.
 // The result is always not nil, even if the resulting type is impossible to successfully type check against.
 //
 // NB: the exact error type may fail an equality check where a IsOfType() check would succeed.
@@ -125,7 +207,13 @@ func (e *Error) Type() *Type {
 			return cause.errorType
 		}
 
-		cause = Cast(cause.Cause())
+		next := Cast(cause.Cause())
+		if next == nil && cause.Cause() != nil {
+			if classified := classifyType(cause.Cause()); classified != nil {
+				return classified
+			}
+		}
+		cause = next
 	}
 
 	return foreignType
@@ -136,6 +224,13 @@ func (e *Error) Type() *Type {
 // In most cases, message is only used as a part of formatting to print error contents into a log file.
 // Manual extraction may be required, however, to transform an error into another format - say, API response.
 func (e *Error) Message() string {
+	if e.message == "" {
+		// a message of an error created from a template is rendered anew, see Type.NewFromTemplate
+		if message, ok := e.templateMessage(); ok {
+			return message.render()
+		}
+	}
+
 	return e.message
 }
 
@@ -177,33 +272,43 @@ func (e *Error) Unwrap() error {
 // In is nearly always preferable to use %+v format.
 // If a stack trace is not required, it should be omitted at the moment of creation rather in formatting.
 func (e *Error) Format(s fmt.State, verb rune) {
-	message := e.fullMessage()
 	switch verb {
 	case 'v':
-		_, _ = io.WriteString(s, message)
-		if s.Flag('+') {
+		verbose := s.Flag('+')
+		_, _ = io.WriteString(s, e.fullMessage(verbose))
+		if verbose {
 			e.stackTrace.Format(s, verb)
 		}
 	case 's':
-		_, _ = io.WriteString(s, message)
+		_, _ = io.WriteString(s, e.fullMessage(false))
 	}
 }
 
 // Error implements the error interface.
 // A result is the same as with %s formatter and does not contain a stack trace.
 func (e *Error) Error() string {
-	return e.fullMessage()
+	return e.fullMessage(false)
 }
 
-func (e *Error) fullMessage() string {
+// fullMessage renders an error message, a verbose one includes the messages of causes omitted otherwise, see TypeModifierOmitCauseMessage
+func (e *Error) fullMessage(verbose bool) string {
 	if e.transparent {
-		return e.messageWithUnderlyingInfo()
+		return e.messageWithUnderlyingInfo(verbose)
+	}
+	return joinStringsIfNonEmpty(": ", e.typeName(), e.messageWithUnderlyingInfo(verbose))
+}
+
+func (e *Error) typeName() string {
+	if includeCodesInMessages() {
+		if code := e.errorType.Code(); code != "" {
+			return e.errorType.FullName() + " [" + code + "]"
+		}
 	}
-	return joinStringsIfNonEmpty(": ", e.errorType.FullName(), e.messageWithUnderlyingInfo())
+	return e.errorType.FullName()
 }
 
-func (e *Error) messageWithUnderlyingInfo() string {
-	return joinStringsIfNonEmpty(" ", e.messageText(), e.underlyingInfo())
+func (e *Error) messageWithUnderlyingInfo(verbose bool) string {
+	return joinStringsIfNonEmpty(" ", e.messageText(verbose), e.underlyingInfo())
 }
 
 func (e *Error) underlyingInfo() string {
@@ -227,14 +332,17 @@ func (e *Error) messageFromProperties() string {
 	uniq := make(map[Property]struct{}, e.printablePropertyCount)
 	strs := make([]string, 0, e.printablePropertyCount)
 	for m := e.properties; m != nil; m = m.next {
-		if !m.p.printable {
+		if !m.p.printable || e.isBoundToTemplate(m.p) {
 			continue
 		}
 		if _, ok := uniq[m.p]; ok {
 			continue
 		}
 		uniq[m.p] = struct{}{}
-		strs = append(strs, fmt.Sprintf("%s: %v", m.p.label, m.value))
+		strs = append(strs, fmt.Sprintf("%s: %v", m.p.label, redactedValue(m.p, m.value)))
+	}
+	if len(strs) == 0 {
+		return ""
 	}
 	return "{" + strings.Join(strs, ", ") + "}"
 }
@@ -249,10 +357,51 @@ func (e *Error) underlying() []error {
 	return u.([]error)
 }
 
-func (e *Error) messageText() string {
-	message := joinStringsIfNonEmpty(" ", e.message, e.messageFromProperties())
-	if cause := e.Cause(); cause != nil {
-		return joinStringsIfNonEmpty(", cause: ", message, cause.Error())
+func (e *Error) instanceTraits() []Trait {
+	if !e.hasTraits {
+		return nil
+	}
+	// Note: as with underlying errors, chain of cause should not be traversed here.
+	value, _ := e.properties.get(propertyTraits)
+	traits, _ := value.([]Trait)
+	return traits
+}
+
+// instanceTrait checks if a trait is either added to or masked by this very error, see WithTrait and MaskTraits
+func (e *Error) instanceTrait(key Trait) (possessed bool, ok bool) {
+	if !e.hasTraits {
+		return false, false
+	}
+
+	if containsTrait(e.instanceTraits(), key) {
+		return true, true
+	}
+
+	value, _ := e.properties.get(propertyMaskedTraits)
+	masked, _ := value.([]Trait)
+	for _, trait := range masked {
+		if trait == key || key.implies(trait) {
+			return false, true
+		}
+	}
+
+	return false, false
+}
+
+func (e *Error) messageText(verbose bool) string {
+	message := joinStringsIfNonEmpty(" ", e.Message(), e.messageFromProperties())
+	cause := e.Cause()
+	if cause == nil || (!verbose && e.omitsCauseMessage()) {
+		return message
+	}
+
+	causeMessage := cause.Error()
+	if typedCause := Cast(cause); typedCause != nil {
+		causeMessage = typedCause.fullMessage(verbose)
 	}
-	return message
+	return joinStringsIfNonEmpty(", cause: ", message, causeMessage)
+}
+
+func (e *Error) omitsCauseMessage() bool {
+	return !e.transparent && e.errorType.modifiers.OmitCauseMessage()
 }
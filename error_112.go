@@ -4,7 +4,11 @@ package errorx
 
 func isOfType(err error, t *Type) bool {
 	e := Cast(err)
-	return e != nil && e.IsOfType(t)
+	if e == nil {
+		return isOfClassifiedType(err, t)
+	}
+
+	return e.IsOfType(t)
 }
 
 func (e *Error) isOfType(t *Type) bool {
@@ -14,7 +18,11 @@ func (e *Error) isOfType(t *Type) bool {
 			return cause.errorType.IsOfType(t)
 		}
 
-		cause = Cast(cause.Cause())
+		next := Cast(cause.Cause())
+		if next == nil {
+			return isOfClassifiedType(cause.Cause(), t)
+		}
+		cause = next
 	}
 
 	return false
@@ -6,7 +6,11 @@ import "errors"
 
 func isOfType(err error, t *Type) bool {
 	e := burrowForTyped(err)
-	return e != nil && e.IsOfType(t)
+	if e == nil {
+		return isOfClassifiedType(err, t)
+	}
+
+	return e.IsOfType(t)
 }
 
 func (e *Error) isOfType(t *Type) bool {
@@ -16,7 +20,11 @@ func (e *Error) isOfType(t *Type) bool {
 			return cause.errorType.IsOfType(t)
 		}
 
-		cause = burrowForTyped(cause.Cause())
+		next := burrowForTyped(cause.Cause())
+		if next == nil {
+			return isOfClassifiedType(cause.Cause(), t)
+		}
+		cause = next
 	}
 
 	return false
@@ -0,0 +1,63 @@
+package errorxcheck
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// BuilderCause reports ErrorBuilder wrap modifiers used without a cause.
+var BuilderCause = &analysis.Analyzer{
+	Name: "errorxbuildercause",
+	Doc: `report ErrorBuilder wrap modifiers used without a cause
+
+ErrorBuilder.Transparent() and ErrorBuilder.EnhanceStackTrace() panic
+unless a non-nil cause is provided with ErrorBuilder.WithCause() beforehand.`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runBuilderCause,
+}
+
+func runBuilderCause(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+		fn := errorxCallee(pass.TypesInfo, call)
+		if !isErrorxFunc(fn, "ErrorBuilder", "Transparent", "EnhanceStackTrace") {
+			return
+		}
+
+		selector := call.Fun.(*ast.SelectorExpr)
+		switch cause := builderCause(pass, selector.X); {
+		case cause == nil:
+			pass.ReportRangef(selector.Sel, "ErrorBuilder.%s() without a cause panics: provide one with WithCause()", fn.Name())
+		case pass.TypesInfo.Types[cause].IsNil():
+			pass.ReportRangef(selector.Sel, "ErrorBuilder.%s() with a nil cause panics", fn.Name())
+		}
+	})
+	return nil, nil
+}
+
+// builderCause follows a chain of builder calls back to NewErrorBuilder and returns the argument of WithCause().
+// The result is nil if there is definitely no cause, and the builder itself if the chain cannot be followed.
+func builderCause(pass *analysis.Pass, builder ast.Expr) ast.Expr {
+	for {
+		call, ok := unparen(builder).(*ast.CallExpr)
+		if !ok {
+			return builder
+		}
+
+		fn := errorxCallee(pass.TypesInfo, call)
+		switch {
+		case isErrorxFunc(fn, "", "NewErrorBuilder"):
+			return nil
+		case isErrorxFunc(fn, "ErrorBuilder", "WithCause"):
+			return call.Args[0]
+		case fn != nil && receiverName(fn) == "ErrorBuilder":
+			builder = call.Fun.(*ast.SelectorExpr).X
+		default:
+			return builder
+		}
+	}
+}
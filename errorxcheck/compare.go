@@ -0,0 +1,71 @@
+package errorxcheck
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// TypeComparison reports equality checks of an error type.
+var TypeComparison = &analysis.Analyzer{
+	Name: "errorxtypecompare",
+	Doc: `report equality checks of an error type
+
+Error.Type() returns the exact type of an error, so comparing it with
+another type fails for any of its subtypes. Error.IsOfType() takes
+the type hierarchy into account and should be used instead.`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runTypeComparison,
+}
+
+func runTypeComparison(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.BinaryExpr)(nil)}, func(node ast.Node) {
+		binary := node.(*ast.BinaryExpr)
+		if binary.Op != token.EQL && binary.Op != token.NEQ {
+			return
+		}
+
+		typeCall, other := binary.X, binary.Y
+		receiver := errorTypeReceiver(pass, typeCall)
+		if receiver == nil {
+			typeCall, other = binary.Y, binary.X
+			receiver = errorTypeReceiver(pass, typeCall)
+		}
+		if receiver == nil || errorxTypeName(pass.TypesInfo.TypeOf(other)) != "Type" {
+			return
+		}
+
+		replacement := render(pass.Fset, receiver) + ".IsOfType(" + render(pass.Fset, other) + ")"
+		if binary.Op == token.NEQ {
+			replacement = "!" + replacement
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     binary.Pos(),
+			End:     binary.End(),
+			Message: "comparison of the exact error type fails for subtypes: use IsOfType() instead",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message: "Replace with IsOfType()",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     binary.Pos(),
+					End:     binary.End(),
+					NewText: []byte(replacement),
+				}},
+			}},
+		})
+	})
+	return nil, nil
+}
+
+// errorTypeReceiver returns the error of an Error.Type() call, or nil for any other expression
+func errorTypeReceiver(pass *analysis.Pass, expr ast.Expr) ast.Expr {
+	call, ok := unparen(expr).(*ast.CallExpr)
+	if !ok || !isErrorxFunc(errorxCallee(pass.TypesInfo, call), "Error", "Type") {
+		return nil
+	}
+	return call.Fun.(*ast.SelectorExpr).X
+}
@@ -0,0 +1,102 @@
+package errorxcheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// LocalDeclaration reports namespaces, types, traits and properties declared inside functions.
+var LocalDeclaration = &analysis.Analyzer{
+	Name: "errorxlocaldecl",
+	Doc: `report errorx declarations inside functions
+
+Each namespace, type, trait and property is registered for the lifetime
+of a process, so a declaration inside a function leaks a new entity into
+the registry on every call. Declare those in package-level variables,
+or use an isolated errorx.Registry, for example in tests.`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runLocalDeclaration,
+}
+
+func runLocalDeclaration(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node, push bool, stack []ast.Node) bool {
+		if !push || !insideFunction(stack) {
+			return true
+		}
+
+		call := node.(*ast.CallExpr)
+		fn := errorxCallee(pass.TypesInfo, call)
+		if entity := declaredEntity(pass, call, fn); entity != "" {
+			pass.ReportRangef(call, "%s declared inside a function is registered anew on each call: declare it in a package-level variable", entity)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// insideFunction checks whether a node belongs to a function body other than init()
+func insideFunction(stack []ast.Node) bool {
+	for _, node := range stack {
+		if decl, ok := node.(*ast.FuncDecl); ok {
+			return decl.Recv != nil || decl.Name.Name != "init"
+		}
+	}
+	return false
+}
+
+// declaredEntity describes an entity declared by a call within the process-wide registry, if any
+func declaredEntity(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func) string {
+	switch {
+	case isErrorxFunc(fn, "", "NewNamespace"):
+		return "namespace " + displayName(fn)
+	case isErrorxFunc(fn, "", "RegisterTrait"):
+		return "trait " + displayName(fn)
+	case isErrorxFunc(fn, "", "RegisterProperty", "RegisterPrintableProperty"):
+		return "property " + displayName(fn)
+	case isErrorxFunc(fn, "", "NewType") && len(call.Args) > 0 && isGlobal(pass, call.Args[0]):
+		return "type " + displayName(fn)
+	case isErrorxFunc(fn, "Namespace", "NewSubNamespace") && isGlobal(pass, call.Fun.(*ast.SelectorExpr).X):
+		return "namespace " + displayName(fn)
+	case isErrorxFunc(fn, "Namespace", "NewType") && isGlobal(pass, call.Fun.(*ast.SelectorExpr).X):
+		return "type " + displayName(fn)
+	case isErrorxFunc(fn, "Type", "NewSubtype") && isGlobal(pass, call.Fun.(*ast.SelectorExpr).X):
+		return "type " + displayName(fn)
+	default:
+		return ""
+	}
+}
+
+// isGlobal checks whether a namespace or a type is known to belong to the process-wide registry.
+// Those declared in package-level variables or by package-level errorx functions do,
+// while those stored in local variables or obtained from an isolated Registry are presumed not to.
+func isGlobal(pass *analysis.Pass, expr ast.Expr) bool {
+	switch expr := unparen(expr).(type) {
+	case *ast.Ident:
+		return isPackageVar(pass.TypesInfo.Uses[expr])
+	case *ast.SelectorExpr:
+		return isPackageVar(pass.TypesInfo.Uses[expr.Sel])
+	case *ast.CallExpr:
+		fn := errorxCallee(pass.TypesInfo, expr)
+		switch {
+		case fn == nil || receiverName(fn) == "Registry":
+			return false
+		case receiverName(fn) == "":
+			return fn.Name() != "NewType" || isGlobal(pass, expr.Args[0])
+		default:
+			selector, ok := expr.Fun.(*ast.SelectorExpr)
+			return ok && isGlobal(pass, selector.X)
+		}
+	default:
+		return false
+	}
+}
+
+func isPackageVar(obj types.Object) bool {
+	v, ok := obj.(*types.Var)
+	return ok && !v.IsField() && v.Pkg() != nil && v.Parent() == v.Pkg().Scope()
+}
@@ -0,0 +1,124 @@
+package errorxcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ErrorfWrap reports errorx errors formatted into fmt.Errorf as text.
+var ErrorfWrap = &analysis.Analyzer{
+	Name: "errorxerrorf",
+	Doc: `report errorx errors formatted by fmt.Errorf with %v or %s
+
+An error formatted as text loses its type, traits and stack trace,
+so that a type check of the result fails. Wrap an error with %w,
+or better yet, use errorx.Decorate() or Type.Wrap() instead.`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runErrorfWrap,
+}
+
+func runErrorfWrap(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "fmt" || fn.Name() != "Errorf" || len(call.Args) < 2 || call.Ellipsis.IsValid() {
+			return
+		}
+
+		format := call.Args[0]
+		value := pass.TypesInfo.Types[format].Value
+		if value == nil || value.Kind() != constant.String {
+			return
+		}
+
+		for _, verb := range parseVerbs(constant.StringVal(value)) {
+			if verb.arg+1 >= len(call.Args) || (verb.verb != 'v' && verb.verb != 's') {
+				continue
+			}
+
+			arg := call.Args[verb.arg+1]
+			if errorxTypeName(pass.TypesInfo.TypeOf(arg)) != "Error" {
+				continue
+			}
+
+			diagnostic := analysis.Diagnostic{
+				Pos:     arg.Pos(),
+				End:     arg.End(),
+				Message: "errorx error formatted with %" + string(verb.verb) + " loses its type and stack trace: use %w or errorx.Decorate()",
+			}
+			if pos, ok := verbPosition(format, verb); ok {
+				diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+					Message: "Wrap the error with %w",
+					TextEdits: []analysis.TextEdit{{
+						Pos:     pos,
+						End:     pos + 1,
+						NewText: []byte("w"),
+					}},
+				}}
+			}
+			pass.Report(diagnostic)
+		}
+	})
+	return nil, nil
+}
+
+// formatVerb is a verb of a format string along with the index of its argument
+type formatVerb struct {
+	verb   rune
+	offset int
+	arg    int
+	plain  bool
+}
+
+// parseVerbs lists the verbs of a format string.
+// Parsing stops at an explicit argument index, as the rest of the arguments cannot be matched reliably.
+func parseVerbs(format string) []formatVerb {
+	var verbs []formatVerb
+	arg := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		start := i
+		for i++; i < len(format) && strings.IndexByte("+-# 0123456789.*", format[i]) >= 0; i++ {
+			if format[i] == '*' {
+				arg++
+			}
+		}
+		if i >= len(format) || format[i] == '[' {
+			break
+		}
+		if format[i] == '%' {
+			continue
+		}
+
+		verbs = append(verbs, formatVerb{verb: rune(format[i]), offset: i, arg: arg, plain: i == start+1})
+		arg++
+	}
+	return verbs
+}
+
+// verbPosition finds a verb in the source of a format string, unless it is not a plain one in a literal without escapes
+func verbPosition(format ast.Expr, verb formatVerb) (token.Pos, bool) {
+	literal, ok := unparen(format).(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING || !verb.plain {
+		return token.NoPos, false
+	}
+
+	value, err := strconv.Unquote(literal.Value)
+	if err != nil || literal.Value[1:len(literal.Value)-1] != value {
+		return token.NoPos, false
+	}
+	return literal.Pos() + token.Pos(1+verb.offset), true
+}
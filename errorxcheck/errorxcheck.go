@@ -0,0 +1,90 @@
+// Package errorxcheck provides analyzers which report misuse of errorx.
+// The analyzers may be used with any driver of golang.org/x/tools/go/analysis, such as a multichecker of a linter.
+//
+// Analyzers may be run with go vet by means of the errorx-vet command:
+//
+//	go install github.com/joomcode/errorx/cmd/errorx-vet
+//	go vet -vettool=$(which errorx-vet) ./...
+package errorxcheck
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// Analyzers is the complete suite of errorx analyzers.
+var Analyzers = []*analysis.Analyzer{
+	BuilderCause,
+	FormatString,
+	TypeComparison,
+	LocalDeclaration,
+	ErrorfWrap,
+}
+
+const errorxPath = "github.com/joomcode/errorx"
+
+// errorxCallee returns the errorx function or method called, if any
+func errorxCallee(info *types.Info, call *ast.CallExpr) *types.Func {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != errorxPath {
+		return nil
+	}
+	return fn
+}
+
+// isErrorxFunc checks whether fn is one of the named errorx functions, or methods of a receiver type if recv is not empty
+func isErrorxFunc(fn *types.Func, recv string, names ...string) bool {
+	if fn == nil || receiverName(fn) != recv {
+		return false
+	}
+	for _, name := range names {
+		if fn.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func receiverName(fn *types.Func) string {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return ""
+	}
+	return errorxTypeName(recv.Type())
+}
+
+// errorxTypeName returns a name of an errorx type, or of a pointer to one
+func errorxTypeName(t types.Type) string {
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != errorxPath {
+		return ""
+	}
+	return named.Obj().Name()
+}
+
+func unparen(expr ast.Expr) ast.Expr {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = paren.X
+	}
+}
+
+func render(fset *token.FileSet, node ast.Node) string {
+	var b bytes.Buffer
+	if err := printer.Fprint(&b, fset, node); err != nil {
+		panic(err)
+	}
+	return b.String()
+}
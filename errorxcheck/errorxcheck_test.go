@@ -0,0 +1,27 @@
+package errorxcheck
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestBuilderCause(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), BuilderCause, "./buildercause")
+}
+
+func TestFormatString(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), FormatString, "./format")
+}
+
+func TestTypeComparison(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), TypeComparison, "./compare")
+}
+
+func TestLocalDeclaration(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), LocalDeclaration, "./declare")
+}
+
+func TestErrorfWrap(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), ErrorfWrap, "./errorf")
+}
@@ -0,0 +1,123 @@
+package errorxcheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// FormatString reports non-constant format strings used with arguments.
+var FormatString = &analysis.Analyzer{
+	Name: "errorxformat",
+	Doc: `report non-constant format strings in errorx calls with arguments
+
+A message of Type.New(), Type.Wrap(), Decorate() and the like is used as is
+without arguments, and as a format string otherwise. With arguments,
+a non-constant message may contain unexpected verbs and garble the output.`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runFormatString,
+}
+
+func runFormatString(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		call := node.(*ast.CallExpr)
+		fn := errorxCallee(pass.TypesInfo, call)
+		if fn == nil {
+			return true
+		}
+
+		index, ok := formatIndex(fn)
+		if !ok || len(call.Args) <= index+1 || isForwarded(pass, call, index, stack) {
+			return true
+		}
+
+		if format := call.Args[index]; pass.TypesInfo.Types[format].Value == nil {
+			pass.ReportRangef(format, "non-constant format string in call to %s", displayName(fn))
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// isForwarded checks whether a call passes on both the format and the arguments of an enclosing printf-like function
+func isForwarded(pass *analysis.Pass, call *ast.CallExpr, index int, stack []ast.Node) bool {
+	if !call.Ellipsis.IsValid() || len(call.Args) != index+2 {
+		return false
+	}
+
+	var signature *ast.FuncType
+	for i := len(stack) - 1; i >= 0 && signature == nil; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			signature = fn.Type
+		case *ast.FuncLit:
+			signature = fn.Type
+		}
+	}
+	if signature == nil || len(signature.Params.List) < 2 {
+		return false
+	}
+
+	params := signature.Params.List
+	variadic := params[len(params)-1]
+	if _, ok := variadic.Type.(*ast.Ellipsis); !ok {
+		return false
+	}
+
+	format, ok := unparen(call.Args[index]).(*ast.Ident)
+	if !ok || !declaresParam(pass, params[:len(params)-1], format) {
+		return false
+	}
+	args, ok := unparen(call.Args[index+1]).(*ast.Ident)
+	return ok && declaresParam(pass, params[len(params)-1:], args)
+}
+
+func declaresParam(pass *analysis.Pass, fields []*ast.Field, ident *ast.Ident) bool {
+	obj := pass.TypesInfo.Uses[ident]
+	for _, field := range fields {
+		for _, name := range field.Names {
+			if obj != nil && pass.TypesInfo.Defs[name] == obj {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// formatIndex finds a format parameter, which is a string followed by variadic ...interface{} arguments
+func formatIndex(fn *types.Func) (int, bool) {
+	signature := fn.Type().(*types.Signature)
+	params := signature.Params()
+	if !signature.Variadic() || params.Len() < 2 {
+		return 0, false
+	}
+
+	variadic, ok := params.At(params.Len() - 1).Type().(*types.Slice)
+	if !ok {
+		return 0, false
+	}
+	if elem, ok := variadic.Elem().Underlying().(*types.Interface); !ok || !elem.Empty() {
+		return 0, false
+	}
+
+	index := params.Len() - 2
+	if basic, ok := params.At(index).Type().(*types.Basic); !ok || basic.Kind() != types.String {
+		return 0, false
+	}
+	return index, true
+}
+
+func displayName(fn *types.Func) string {
+	if recv := receiverName(fn); recv != "" {
+		return recv + "." + fn.Name()
+	}
+	return "errorx." + fn.Name()
+}
@@ -0,0 +1,26 @@
+package buildercause
+
+import "github.com/joomcode/errorx"
+
+func transparent(cause error) error {
+	return errorx.NewErrorBuilder(errorx.IllegalState).WithCause(cause).Transparent().Create()
+}
+
+func missing() error {
+	return errorx.NewErrorBuilder(errorx.IllegalState).Transparent().Create() // want `ErrorBuilder.Transparent\(\) without a cause panics`
+}
+
+func missingAfterMessage() error {
+	return errorx.NewErrorBuilder(errorx.IllegalState).
+		WithConditionallyFormattedMessage("failed").
+		EnhanceStackTrace(). // want `ErrorBuilder.EnhanceStackTrace\(\) without a cause panics`
+		Create()
+}
+
+func nilCause() error {
+	return errorx.NewErrorBuilder(errorx.IllegalState).WithCause(nil).EnhanceStackTrace().Create() // want `ErrorBuilder.EnhanceStackTrace\(\) with a nil cause panics`
+}
+
+func unknown(builder errorx.ErrorBuilder) error {
+	return builder.Transparent().Create()
+}
@@ -0,0 +1,13 @@
+package compare
+
+import "github.com/joomcode/errorx"
+
+func compare(e *errorx.Error, err error, t *errorx.Type) bool {
+	if e.Type() == errorx.IllegalState { // want `comparison of the exact error type fails for subtypes`
+		return true
+	}
+	if errorx.TimeoutElapsed != errorx.Cast(err).Type() { // want `comparison of the exact error type fails for subtypes`
+		return false
+	}
+	return t == errorx.IllegalState
+}
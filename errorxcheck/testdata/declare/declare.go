@@ -0,0 +1,29 @@
+package declare
+
+import "github.com/joomcode/errorx"
+
+var (
+	Namespace = errorx.NewNamespace("declare")
+	Type      = Namespace.NewType("type")
+	registry  = errorx.NewRegistry()
+)
+
+func init() {
+	_ = errorx.RegisterTrait("init")
+}
+
+func declare() {
+	_ = errorx.NewNamespace("local")              // want `namespace errorx.NewNamespace declared inside a function`
+	_ = Namespace.NewSubNamespace("local")        // want `namespace Namespace.NewSubNamespace declared inside a function`
+	_ = Namespace.NewType("local")                // want `type Namespace.NewType declared inside a function`
+	_ = errorx.NewType(Namespace, "local")        // want `type errorx.NewType declared inside a function`
+	_ = Type.NewSubtype("local")                  // want `type Type.NewSubtype declared inside a function`
+	_ = errorx.IllegalState.NewSubtype("local")   // want `type Type.NewSubtype declared inside a function`
+	_ = errorx.RegisterTrait("local")             // want `trait errorx.RegisterTrait declared inside a function`
+	_ = errorx.RegisterPrintableProperty("local") // want `property errorx.RegisterPrintableProperty declared inside a function`
+	_ = errorx.NewRegistry().NewNamespace("isolated").NewType("isolated")
+	_ = registry.RegisterTrait("isolated")
+
+	isolated := registry.NewNamespace("isolated")
+	_ = isolated.NewType("isolated").NewSubtype("isolated")
+}
@@ -0,0 +1,16 @@
+package errorf
+
+import (
+	"fmt"
+
+	"github.com/joomcode/errorx"
+)
+
+func wrap(e *errorx.Error, err error) {
+	_ = fmt.Errorf("failed: %v", e)                // want `errorx error formatted with %v loses its type and stack trace`
+	_ = fmt.Errorf("%d attempts failed: %s", 2, e) // want `errorx error formatted with %s loses its type and stack trace`
+	_ = fmt.Errorf("failed: %+v", e)               // want `errorx error formatted with %v loses its type and stack trace`
+	_ = fmt.Errorf("failed: %v", err)
+	_ = fmt.Errorf("failed: %w", e)
+	_ = fmt.Errorf("failed: %d%%, %v", 1, errorx.IllegalState.New("x")) // want `errorx error formatted with %v loses its type and stack trace`
+}
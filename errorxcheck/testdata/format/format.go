@@ -0,0 +1,23 @@
+package format
+
+import "github.com/joomcode/errorx"
+
+func messages(message string, err error, args []interface{}) {
+	_ = errorx.IllegalState.New("constant %d", 1)
+	_ = errorx.IllegalState.New(message)
+	_ = errorx.IllegalState.New(message, 1)         // want `non-constant format string in call to Type.New`
+	_ = errorx.IllegalState.Wrap(err, message, 1)   // want `non-constant format string in call to Type.Wrap`
+	_ = errorx.Decorate(err, message, args...)      // want `non-constant format string in call to errorx.Decorate`
+	_ = errorx.EnhanceStackTrace(err, message, "x") // want `non-constant format string in call to errorx.EnhanceStackTrace`
+	_ = errorx.NewErrorBuilder(errorx.IllegalState).
+		WithConditionallyFormattedMessage(message, 1) // want `non-constant format string in call to ErrorBuilder.WithConditionallyFormattedMessage`
+}
+
+func forward(message string, args ...interface{}) error {
+	return errorx.IllegalState.New(message, args...)
+}
+
+func forwardOther(message string, args ...interface{}) error {
+	other := message + ": %v"
+	return errorx.IllegalState.New(other, args...) // want `non-constant format string in call to Type.New`
+}
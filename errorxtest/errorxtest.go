@@ -0,0 +1,39 @@
+// Package errorxtest provides test helpers for the code that declares errorx namespaces, types and traits.
+package errorxtest
+
+import (
+	"testing"
+
+	"github.com/joomcode/errorx"
+)
+
+// AssertUniqueNames checks that all namespaces, types and traits registered in a test binary have distinct names.
+// Each duplicate is reported as a test error, see errorx.FindDuplicateNames.
+// Returns true if all the names are unique.
+//
+// As errors are typically declared in package variables, a test in the main package of a binary
+// verifies all the names the binary may ever report:
+//
+//	func TestErrorNames(t *testing.T) {
+//		errorxtest.AssertUniqueNames(t)
+//	}
+func AssertUniqueNames(t testing.TB) bool {
+	t.Helper()
+	return assertNoDuplicates(t, errorx.FindDuplicateNames())
+}
+
+// AssertUniqueNamesInRegistry is the same as AssertUniqueNames, but for an isolated registry.
+func AssertUniqueNamesInRegistry(t testing.TB, registry *errorx.Registry) bool {
+	t.Helper()
+	return assertNoDuplicates(t, registry.FindDuplicateNames())
+}
+
+func assertNoDuplicates(t testing.TB, duplicates []errorx.DuplicateName) bool {
+	t.Helper()
+
+	for _, d := range duplicates {
+		t.Errorf("errorx: duplicate name, %s", d)
+	}
+
+	return len(duplicates) == 0
+}
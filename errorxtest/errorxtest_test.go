@@ -0,0 +1,43 @@
+package errorxtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/joomcode/errorx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertUniqueNames(t *testing.T) {
+	require.True(t, AssertUniqueNames(t))
+}
+
+func TestAssertUniqueNamesInRegistry(t *testing.T) {
+	t.Run("Unique", func(t *testing.T) {
+		registry := errorx.NewRegistry()
+		namespace := registry.NewNamespace("errorxtest")
+		namespace.NewType("unique")
+
+		require.True(t, AssertUniqueNamesInRegistry(t, registry))
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		registry := errorx.NewRegistry()
+		namespace := registry.NewNamespace("errorxtest")
+		namespace.NewType("duplicate")
+		namespace.NewType("duplicate")
+
+		recorder := &recordingT{TB: t}
+		require.False(t, AssertUniqueNamesInRegistry(recorder, registry))
+		require.Equal(t, []string{"errorx: duplicate name, type 'errorxtest.duplicate' is registered 2 times"}, recorder.errors)
+	})
+}
+
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
@@ -85,19 +85,17 @@ func ExampleError_Format() {
 }
 
 func ExampleEnhanceStackTrace() {
-	errCh := make(chan error)
-	go func() {
-		errCh <- nestedCall()
-	}()
+	// an error returned from another goroutine is enhanced with the stack trace of the spawning site by a Group
+	var group errorx.Group
+	group.Go(nestedCall)
 
-	err := <-errCh
-	verboseOutput := fmt.Sprintf("Error full: %+v", errorx.EnhanceStackTrace(err, "another goroutine"))
+	verboseOutput := fmt.Sprintf("Error full: %+v", group.Wait())
 	fmt.Println(verboseOutput)
 
 	// Example output:
-	//Error full: another goroutine, cause: common.assertion_failed: example
+	//Error full: common.assertion_failed: example
 	// at github.com/joomcode/errorx_test.ExampleEnhanceStackTrace()
-	//	/Users/username/go/src/github.com/joomcode/errorx/example_test.go:94
+	//	/Users/username/go/src/github.com/joomcode/errorx/example_test.go:90
 	// at testing.runExample()
 	//	/usr/local/Cellar/go/1.10.3/libexec/src/testing/example.go:122
 	// at testing.runExamples()
@@ -110,15 +108,35 @@ func ExampleEnhanceStackTrace() {
 	// (1 duplicated frames)
 	// ----------------------------------
 	// at github.com/joomcode/errorx_test.someFunc()
-	//	/Users/username/go/src/github.com/joomcode/errorx/example_test.go:106
+	//	/Users/username/go/src/github.com/joomcode/errorx/example_test.go:260
 	// at github.com/joomcode/errorx_test.nestedCall()
-	//	/Users/username/go/src/github.com/joomcode/errorx/example_test.go:102
-	// at github.com/joomcode/errorx_test.ExampleEnhanceStackTrace.func1()
-	//	/Users/username/go/src/github.com/joomcode/errorx/example_test.go:90
+	//	/Users/username/go/src/github.com/joomcode/errorx/example_test.go:256
+	// at github.com/joomcode/errorx.Try()
+	//	/Users/username/go/src/github.com/joomcode/errorx/panic.go:81
+	// at github.com/joomcode/errorx.(*Group).Go.func1()
+	//	/Users/username/go/src/github.com/joomcode/errorx/group.go:40
 	// at runtime.goexit()
 	//	/usr/local/Cellar/go/1.10.3/libexec/src/runtime/asm_amd64.s:2361
 }
 
+func ExampleGroup() {
+	var group errorx.Group
+	group.Go(func() error {
+		return nil
+	})
+	group.Go(func() error {
+		return nestedCall()
+	})
+
+	err := group.Wait()
+	fmt.Println(err.Error())
+	fmt.Println(errorx.IsOfType(err, errorx.AssertionFailed))
+
+	// Output:
+	// common.assertion_failed: example
+	// true
+}
+
 func ExampleIgnore() {
 	err := errorx.IllegalArgument.NewWithNoMessage()
 	err = errorx.Decorate(err, "more info")
@@ -0,0 +1,86 @@
+package errorx
+
+import (
+	"context"
+	"sync"
+)
+
+// Group is a collection of goroutines working on subtasks of the same task, in a manner similar to errgroup.Group.
+// A panic in a goroutine is recovered and reported as an error, see Recover().
+// Each error is decorated with a stack trace of the site the goroutine was spawned from, see EnhanceStackTrace(),
+// so that the formatted output holds both the stack trace of the worker and that of the spawning site.
+//
+// A zero Group waits for all goroutines and reports all of their errors, see GroupWithContext() for a fail-fast alternative.
+// A Group must not be copied after first use.
+type Group struct {
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	errs     []error
+	failFast bool
+	cancel   func()
+}
+
+// GroupWithContext returns a new fail-fast Group and a derived context.
+// The derived context is canceled either when some goroutine returns an error or when Wait() returns, whichever occurs first.
+// For a fail-fast Group, Wait() returns the first error only.
+func GroupWithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{failFast: true, cancel: cancel}, ctx
+}
+
+// Go calls the provided function in a new goroutine.
+// A non-nil error returned by the function or a panic in it is reported as a failure of the whole Group.
+func (g *Group) Go(fn func() error) {
+	spawnStackTrace := collectCallerStackTrace()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		if err := Try(fn); err != nil {
+			g.fail(newGroupError(err, spawnStackTrace))
+		}
+	}()
+}
+
+// Wait blocks until all goroutines have returned, then returns their errors, if any.
+// For a zero Group, all errors are combined as with DecorateMany(), so the result is nil only if all goroutines succeeded.
+// For a fail-fast Group, see GroupWithContext(), only the first error is returned.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+
+	if g.failFast {
+		if len(g.errs) == 0 {
+			return nil
+		}
+		return g.errs[0]
+	}
+
+	return DecorateMany("", g.errs...)
+}
+
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.failFast && len(g.errs) > 0 {
+		return
+	}
+
+	g.errs = append(g.errs, err)
+	if g.cancel != nil {
+		g.cancel()
+	}
+}
+
+// newGroupError has all the properties of EnhanceStackTrace(),
+// except that the stack trace of the spawning site is used instead of the current one.
+func newGroupError(err error, spawnStackTrace *stackTrace) *Error {
+	return NewErrorBuilder(transparentWrapper).
+		WithCause(err).
+		enhanceStackTraceFrom(spawnStackTrace).
+		Create()
+}
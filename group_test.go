@@ -0,0 +1,129 @@
+package errorx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("NoErrors", func(t *testing.T) {
+		var group Group
+		group.Go(func() error { return nil })
+		group.Go(func() error { return nil })
+		require.NoError(t, group.Wait())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var group Group
+		require.NoError(t, group.Wait())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var group Group
+		group.Go(func() error { return nil })
+		group.Go(funcWithErr)
+
+		err := group.Wait()
+		require.True(t, IsOfType(err, testType))
+		require.Equal(t, "foo.bar: bad", err.Error())
+	})
+
+	t.Run("AllErrors", func(t *testing.T) {
+		var group Group
+		group.Go(funcWithErr)
+		group.Go(funcWithErr)
+		group.Go(funcWithBadErr)
+
+		err := group.Wait()
+		require.Error(t, err)
+		require.False(t, IsOfType(err, testType))
+		require.Contains(t, err.Error(), "bad")
+		require.Contains(t, err.Error(), "awful")
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		var group Group
+		group.Go(func() error {
+			funcWithBadPanic()
+			return nil
+		})
+
+		err := group.Wait()
+		require.True(t, IsOfType(err, RecoveredPanic))
+		require.Contains(t, err.Error(), "awful")
+	})
+
+	t.Run("StackTrace", func(t *testing.T) {
+		var group Group
+		spawnGroupWorker(&group)
+
+		output := fmt.Sprintf("%+v", group.Wait())
+		require.Contains(t, output, "errorx.funcWithErr()", output)
+		require.Contains(t, output, "errorx.spawnGroupWorker()", output)
+		require.NotContains(t, output, "errorx.(*Group).Go()", output)
+	})
+
+	t.Run("StackTraceRaw", func(t *testing.T) {
+		var group Group
+		group.Go(funcWithBadErr)
+
+		err := group.Wait()
+		require.Equal(t, "awful", err.Error())
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "TestGroup", output)
+	})
+
+	t.Run("Properties", func(t *testing.T) {
+		var group Group
+		group.Go(func() error {
+			err := testType.New("bad")
+			for i := 0; i < propertyIndexThreshold; i++ {
+				err = err.WithProperty(RegisterProperty(fmt.Sprintf("group%d", i)), i)
+			}
+			return err.WithProperty(testProperty0, 42)
+		})
+
+		err := Cast(group.Wait())
+		require.NotNil(t, err)
+		value, ok := err.Property(testProperty0)
+		require.True(t, ok)
+		require.Equal(t, 42, value)
+		require.Len(t, err.Properties(), propertyIndexThreshold+1)
+	})
+}
+
+func TestGroupWithContext(t *testing.T) {
+	t.Run("NoErrors", func(t *testing.T) {
+		group, ctx := GroupWithContext(context.Background())
+		group.Go(func() error { return nil })
+
+		require.NoError(t, group.Wait())
+		require.Error(t, ctx.Err())
+	})
+
+	t.Run("FailFast", func(t *testing.T) {
+		group, ctx := GroupWithContext(context.Background())
+		group.Go(funcWithErr)
+		group.Go(func() error {
+			select {
+			case <-ctx.Done():
+				return errors.New("canceled")
+			case <-time.After(time.Second):
+				return AssertionFailed.New("expected cancellation")
+			}
+		})
+
+		err := group.Wait()
+		require.True(t, IsOfType(err, testType))
+		require.Equal(t, "foo.bar: bad", err.Error())
+	})
+}
+
+func spawnGroupWorker(group *Group) {
+	group.Go(funcWithErr)
+}
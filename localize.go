@@ -0,0 +1,297 @@
+package errorx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// GenericMessageKey is a key of a template used for the errors that have no template of their own, non-errorx errors included.
+const GenericMessageKey = "*"
+
+// MessageBundle is a set of localized message templates for a single locale.
+// A template is bound to a key, which is either a code of an error type, a full name of an error type or a full name of a namespace,
+// see Localize for details. A template may contain named placeholders, such as '{user_id}',
+// which are replaced with the values of error properties with the same label. Use '{{' and '}}' for literal braces.
+// A bundle may be modified after it is registered, and a localizer then uses the templates bound to it so far.
+type MessageBundle struct {
+	mu        sync.RWMutex
+	locale    string
+	templates map[string]string
+}
+
+// NewMessageBundle creates a bundle for a locale with templates by keys.
+func NewMessageBundle(locale string, templates map[string]string) *MessageBundle {
+	bundle := &MessageBundle{
+		locale:    locale,
+		templates: make(map[string]string, len(templates)),
+	}
+
+	for key, template := range templates {
+		bundle.templates[key] = template
+	}
+
+	return bundle
+}
+
+// LoadMessageBundle reads a bundle from a JSON file, which contains a single object with templates by keys.
+// A file name without an extension is a locale of the bundle, such as 'en.json' or 'pt-BR.json'.
+func LoadMessageBundle(path string) (*MessageBundle, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, Decorate(err, "failed to read message bundle")
+	}
+
+	var templates map[string]string
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, IllegalFormat.Wrap(err, "failed to parse message bundle %s", path)
+	}
+
+	locale := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return NewMessageBundle(locale, templates), nil
+}
+
+// Locale returns a locale of this bundle.
+func (b *MessageBundle) Locale() string {
+	return b.locale
+}
+
+// BindType binds a template to an error type by its full name.
+func (b *MessageBundle) BindType(t *Type, template string) *MessageBundle {
+	return b.bind(t.FullName(), template)
+}
+
+// BindNamespace binds a template to a namespace by its full name.
+func (b *MessageBundle) BindNamespace(n Namespace, template string) *MessageBundle {
+	return b.bind(n.FullName(), template)
+}
+
+// Template returns a template bound to a key.
+func (b *MessageBundle) Template(key string) (string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	template, ok := b.templates[key]
+	return template, ok
+}
+
+func (b *MessageBundle) bind(key string, template string) *MessageBundle {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.templates[key] = template
+	return b
+}
+
+// RegisterMessageBundle adds a bundle to the process-wide localizer, see Localizer.RegisterBundle.
+func RegisterMessageBundle(bundle *MessageBundle) {
+	globalLocalizer.RegisterBundle(bundle)
+}
+
+// LoadMessageBundles registers all the bundles in a directory with the process-wide localizer, see Localizer.LoadBundles.
+func LoadMessageBundles(dir string) error {
+	return globalLocalizer.LoadBundles(dir)
+}
+
+// SetDefaultLocale sets a locale the process-wide localizer falls back to, see Localizer.SetDefaultLocale.
+func SetDefaultLocale(locale string) {
+	globalLocalizer.SetDefaultLocale(locale)
+}
+
+// Localize produces a user-facing message for an error with the process-wide localizer, see Localizer.Localize.
+func Localize(err error, locale string) string {
+	return globalLocalizer.Localize(err, locale)
+}
+
+// Localizer produces localized user-facing messages from a number of message bundles.
+// All the bundles are registered in a process-wide localizer by default,
+// while an isolated localizer may be created with NewLocalizer.
+type Localizer struct {
+	mu            sync.RWMutex
+	bundles       map[string]localeBundles
+	defaultLocale string
+}
+
+var globalLocalizer = NewLocalizer()
+
+// NewLocalizer creates a new isolated localizer.
+func NewLocalizer() *Localizer {
+	return &Localizer{bundles: make(map[string]localeBundles)}
+}
+
+// RegisterBundle adds a bundle to this localizer.
+// The bundle is not copied, so the templates bound to it later are used as well, see MessageBundle.BindType.
+// Templates of a bundle take precedence by key over those of bundles previously registered for the same locale, if any.
+func (l *Localizer) RegisterBundle(bundle *MessageBundle) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.bundles[bundle.locale] = append(l.bundles[bundle.locale], bundle)
+}
+
+// LoadBundles registers all the bundles in *.json files of a directory, see LoadMessageBundle.
+func (l *Localizer) LoadBundles(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return Decorate(err, "failed to list message bundles")
+	}
+
+	for _, path := range paths {
+		bundle, err := LoadMessageBundle(path)
+		if err != nil {
+			return err
+		}
+
+		l.RegisterBundle(bundle)
+	}
+
+	return nil
+}
+
+// SetDefaultLocale sets a locale to fall back to if a requested one has no suitable template.
+func (l *Localizer) SetDefaultLocale(locale string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.defaultLocale = locale
+}
+
+// Localize produces a user-facing message for an error in a locale.
+//
+// For an errorx error, a template is searched by the keys in the following order:
+// a code of the error type, its full name, then the same for each of the supertypes,
+// then full names of namespaces of the error type from the innermost outwards, then GenericMessageKey.
+// The type of an error is determined as by Type(), so that transparent wrappers, such as by Decorate, are skipped,
+// and the placeholders are filled with the property values visible as with Property(), sensitive values redacted.
+// For a non-errorx error, only a generic template is used.
+//
+// Bundles are searched by locale, from more to less specific: 'pt-BR', then 'pt', then the default locale, see SetDefaultLocale.
+// The result never contains the message of an error, so that an error without a template is presented with its public message, see PublicMessage.
+func (l *Localizer) Localize(err error, locale string) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	typedErr := Cast(err)
+	keys := localizationKeys(typedErr)
+	for _, bundles := range l.bundleChain(locale) {
+		for _, key := range keys {
+			if template, ok := bundles.template(key); ok {
+				return expandTemplate(template, func(label string) (interface{}, bool) {
+					return propertyByLabel(typedErr, label)
+				})
+			}
+		}
+	}
+
+	return PublicMessage(err)
+}
+
+func (l *Localizer) bundleChain(locale string) []localeBundles {
+	var chain []localeBundles
+	for _, candidate := range append(localeChain(locale), localeChain(l.defaultLocale)...) {
+		if bundle, ok := l.bundles[candidate]; ok {
+			chain = append(chain, bundle)
+		}
+	}
+
+	return chain
+}
+
+// localeBundles are the bundles registered for a locale, in order of registration
+type localeBundles []*MessageBundle
+
+// template finds a template by key, the bundles registered later taking precedence
+func (bundles localeBundles) template(key string) (string, bool) {
+	for i := len(bundles) - 1; i >= 0; i-- {
+		if template, ok := bundles[i].Template(key); ok {
+			return template, true
+		}
+	}
+
+	return "", false
+}
+
+// localeChain lists a locale followed by the less specific ones, such as 'pt-BR', then 'pt'
+func localeChain(locale string) []string {
+	var chain []string
+	for locale != "" {
+		chain = append(chain, locale)
+		if i := strings.LastIndexAny(locale, "-_"); i >= 0 {
+			locale = locale[:i]
+		} else {
+			locale = ""
+		}
+	}
+
+	return chain
+}
+
+func localizationKeys(err *Error) []string {
+	if err == nil {
+		return []string{GenericMessageKey}
+	}
+
+	var keys []string
+	errorType := err.Type()
+	for t := errorType; t != nil; t = t.parent {
+		if code := t.Code(); code != "" {
+			keys = append(keys, code)
+		}
+		keys = append(keys, t.FullName())
+	}
+
+	for n := &errorType.namespace; n != nil; n = n.parent {
+		keys = append(keys, n.FullName())
+	}
+
+	return append(keys, GenericMessageKey)
+}
+
+// expandTemplate replaces the placeholders of a template with values by label, a placeholder without a value is omitted
+func expandTemplate(template string, lookup func(label string) (interface{}, bool)) string {
+	var b strings.Builder
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case (c == '{' || c == '}') && i+1 < len(template) && template[i+1] == c:
+			b.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				b.WriteString(template[i:])
+				return b.String()
+			}
+
+			if value, ok := lookup(template[i+1 : i+end]); ok {
+				b.WriteString(fmt.Sprint(value))
+			}
+			i += end
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// propertyByLabel finds a visible property value by its label, with the same visibility rules as with Property()
+func propertyByLabel(err *Error, label string) (interface{}, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result interface{}
+	found := false
+	err.VisitProperties(func(p Property, value interface{}) bool {
+		if p.label == label {
+			result, found = value, true
+		}
+		return !found
+	})
+
+	return result, found
+}
@@ -0,0 +1,122 @@
+package errorx
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	localizeTestNamespace = NewNamespace("localize")
+	localizeTestChild     = localizeTestNamespace.NewSubNamespace("child")
+	localizeTestType      = localizeTestNamespace.NewType("type")
+	localizeTestSubtype   = localizeTestType.NewSubtype("subtype")
+	localizeTestCoded     = localizeTestType.NewSubtype("coded").WithCode("LOCALIZE-1")
+	localizeTestInherited = localizeTestCoded.NewSubtype("inherited")
+	localizeTestChildType = localizeTestChild.NewType("type")
+	localizeTestProperty  = RegisterProperty("localize_id")
+)
+
+func TestLocalize(t *testing.T) {
+	l := NewLocalizer()
+	l.RegisterBundle(NewMessageBundle("en", map[string]string{
+		"localize.type":       "Type {localize_id} failed",
+		"LOCALIZE-1":          "Coded failed",
+		"localize":            "Namespace failed",
+		GenericMessageKey:     "Something went wrong",
+		"localize.child.type": "Braces {{{localize_id}}} and {missing}",
+	}))
+	l.RegisterBundle(NewMessageBundle("de", nil).
+		BindType(localizeTestType, "Typ {localize_id} fehlgeschlagen").
+		BindNamespace(localizeTestChild, "Kind fehlgeschlagen"))
+	l.SetDefaultLocale("en")
+
+	err := localizeTestType.New("internal detail").WithProperty(localizeTestProperty, 42)
+
+	t.Run("Type", func(t *testing.T) {
+		require.Equal(t, "Type 42 failed", l.Localize(err, "en"))
+		require.Equal(t, "Typ 42 fehlgeschlagen", l.Localize(err, "de"))
+	})
+
+	t.Run("Supertype", func(t *testing.T) {
+		require.Equal(t, "Type 42 failed", l.Localize(localizeTestSubtype.New("internal").WithProperty(localizeTestProperty, 42), "en"))
+		require.Equal(t, "Type  failed", l.Localize(localizeTestSubtype.New("internal"), "en"))
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		require.Equal(t, "Coded failed", l.Localize(localizeTestCoded.New("internal"), "en"))
+		require.Equal(t, "Typ  fehlgeschlagen", l.Localize(localizeTestCoded.New("internal"), "de"))
+		require.Equal(t, "Coded failed", l.Localize(localizeTestInherited.New("internal"), "en"))
+	})
+
+	t.Run("Namespace", func(t *testing.T) {
+		require.Equal(t, "Kind fehlgeschlagen", l.Localize(localizeTestChildType.New("internal"), "de"))
+		require.Equal(t, "Braces {42} and ", l.Localize(localizeTestChildType.New("internal").WithProperty(localizeTestProperty, 42), "en"))
+	})
+
+	t.Run("Locale", func(t *testing.T) {
+		require.Equal(t, "Typ 42 fehlgeschlagen", l.Localize(err, "de-AT"))
+		require.Equal(t, "Typ 42 fehlgeschlagen", l.Localize(err, "de_CH"))
+		require.Equal(t, "Type 42 failed", l.Localize(err, "fr"))
+		require.Equal(t, "Type 42 failed", l.Localize(err, ""))
+	})
+
+	t.Run("Generic", func(t *testing.T) {
+		require.Equal(t, "Something went wrong", l.Localize(errors.New("internal"), "de"))
+		require.Equal(t, "Something went wrong", l.Localize(IllegalState.New("internal"), "en"))
+		require.Equal(t, GenericPublicMessage, NewLocalizer().Localize(err, "en"))
+		require.Equal(t, "Public", NewLocalizer().Localize(err.WithPublicMessage("Public"), "en"))
+	})
+
+	t.Run("Decorate", func(t *testing.T) {
+		require.Equal(t, "Type 42 failed", l.Localize(Decorate(err, "decorated"), "en"))
+		require.Equal(t, "Type 7 failed", l.Localize(Decorate(err, "decorated").WithProperty(localizeTestProperty, 7), "en"))
+		require.Equal(t, "Something went wrong", l.Localize(IllegalState.Wrap(err, "wrapped"), "en"))
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		l := NewLocalizer()
+		bundle := NewMessageBundle("en", map[string]string{"localize.type": "Type failed"})
+		l.RegisterBundle(bundle)
+		l.RegisterBundle(NewMessageBundle("en", map[string]string{"localize": "Namespace failed"}))
+
+		bundle.BindType(localizeTestSubtype, "Subtype failed")
+		require.Equal(t, "Subtype failed", l.Localize(localizeTestSubtype.New("internal"), "en"))
+		require.Equal(t, "Type failed", l.Localize(err, "en"))
+		require.Equal(t, "Namespace failed", l.Localize(localizeTestChildType.New("internal"), "en"))
+
+		l.RegisterBundle(NewMessageBundle("en", map[string]string{"localize.type": "Type failed again"}))
+		require.Equal(t, "Type failed again", l.Localize(err, "en"))
+	})
+}
+
+func TestLoadMessageBundles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "errorx")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"localize.type": "Type failed"}`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "pt-BR.json"), []byte(`{"localize.type": "Tipo falhou"}`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "readme.txt"), []byte(`not a bundle`), 0644))
+
+	l := NewLocalizer()
+	require.NoError(t, l.LoadBundles(dir))
+	require.Equal(t, "Type failed", l.Localize(localizeTestType.New("internal"), "en-GB"))
+	require.Equal(t, "Tipo falhou", l.Localize(localizeTestType.New("internal"), "pt-BR"))
+
+	bundle, err := LoadMessageBundle(filepath.Join(dir, "pt-BR.json"))
+	require.NoError(t, err)
+	require.Equal(t, "pt-BR", bundle.Locale())
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "fr.json"), []byte(`["not", "an", "object"]`), 0644))
+	err = l.LoadBundles(dir)
+	require.Error(t, err)
+	require.True(t, IsOfType(err, IllegalFormat))
+
+	_, err = LoadMessageBundle(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
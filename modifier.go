@@ -1,9 +1,15 @@
 package errorx
 
+import (
+	"strconv"
+	"sync"
+)
+
 // TypeModifier is a way to change a default behaviour for an error type, directly or via type hierarchy.
 // Modification is intentionally one-way, as it provides much more clarity.
 // If there is a modifier on a type or a namespace, all its descendants definitely have the same default behaviour.
 // If some of a subtypes must lack a specific modifier, then the modifier must be removed from the common ancestor.
+// Apart from the built-in modifiers, more kinds of them may be declared, see RegisterTypeModifier.
 type TypeModifier int
 
 const (
@@ -11,11 +17,64 @@ const (
 	TypeModifierTransparent TypeModifier = 1
 	// TypeModifierOmitStackTrace is a type modifier; an error type with such modifier omits the stack trace collection upon creation of an error instance
 	TypeModifierOmitStackTrace TypeModifier = 2
+	// TypeModifierOmitCauseMessage is a type modifier; an opaque wrapper of such type omits the message of its cause from Error(),
+	// so that the internal details of the cause do not leak into a message meant for the outside.
+	// The message of the cause is still present in a verbose output with %+v, and the cause itself remains accessible with Cause().
+	TypeModifierOmitCauseMessage TypeModifier = 3
 )
 
+// RegisterTypeModifier declares a new kind of type modifier, which is applied with ApplyModifiers just as the built-in ones are.
+// Such modifier is inherited the same way: by subtypes, by the types of a namespace and by sub-namespaces.
+// Errorx attaches no behaviour to it, which is up to the user code to check with Type.HasModifier, for example, to choose a log level.
+// A name is used for presentation only, see TypeModifier.String.
+func RegisterTypeModifier(name string) TypeModifier {
+	typeModifierNames.mu.Lock()
+	defer typeModifierNames.mu.Unlock()
+
+	typeModifierNames.names = append(typeModifierNames.names, name)
+	return TypeModifier(len(typeModifierNames.names))
+}
+
+// String returns a name of a modifier, such as 'OmitStackTrace' for TypeModifierOmitStackTrace, or the one it was registered with.
+func (m TypeModifier) String() string {
+	typeModifierNames.mu.RLock()
+	defer typeModifierNames.mu.RUnlock()
+
+	if m > 0 && int(m) <= len(typeModifierNames.names) {
+		return typeModifierNames.names[m-1]
+	}
+	return "TypeModifier(" + strconv.Itoa(int(m)) + ")"
+}
+
+// typeModifierNames holds the names of all the declared modifiers, so that a name of modifier m is at index m-1
+var typeModifierNames = struct {
+	mu    *sync.RWMutex
+	names []string
+}{
+	mu:    &sync.RWMutex{},
+	names: []string{"Transparent", "OmitStackTrace", "OmitCauseMessage"},
+}
+
+// effectiveModifiers lists the modifiers in effect, in order of declaration
+func effectiveModifiers(m modifiers) []TypeModifier {
+	typeModifierNames.mu.RLock()
+	count := len(typeModifierNames.names)
+	typeModifierNames.mu.RUnlock()
+
+	var result []TypeModifier
+	for modifier := TypeModifier(1); int(modifier) <= count; modifier++ {
+		if m.Has(modifier) {
+			result = append(result, modifier)
+		}
+	}
+	return result
+}
+
 type modifiers interface {
 	CollectStackTrace() bool
 	Transparent() bool
+	OmitCauseMessage() bool
+	Has(modifier TypeModifier) bool
 	ReplaceWith(new modifiers) modifiers
 }
 
@@ -34,13 +93,23 @@ func (noModifiers) Transparent() bool {
 	return false
 }
 
+func (noModifiers) OmitCauseMessage() bool {
+	return false
+}
+
+func (noModifiers) Has(modifier TypeModifier) bool {
+	return false
+}
+
 func (noModifiers) ReplaceWith(new modifiers) modifiers {
 	return new
 }
 
 type typeModifiers struct {
-	omitStackTrace bool
-	transparent    bool
+	omitStackTrace   bool
+	transparent      bool
+	omitCauseMessage bool
+	registered       []TypeModifier
 }
 
 func newTypeModifiers(modifiers ...TypeModifier) modifiers {
@@ -51,6 +120,10 @@ func newTypeModifiers(modifiers ...TypeModifier) modifiers {
 			m.omitStackTrace = true
 		case TypeModifierTransparent:
 			m.transparent = true
+		case TypeModifierOmitCauseMessage:
+			m.omitCauseMessage = true
+		default:
+			m.registered = append(m.registered, modifier)
 		}
 	}
 	return m
@@ -64,6 +137,28 @@ func (m typeModifiers) Transparent() bool {
 	return m.transparent
 }
 
+func (m typeModifiers) OmitCauseMessage() bool {
+	return m.omitCauseMessage
+}
+
+func (m typeModifiers) Has(modifier TypeModifier) bool {
+	switch modifier {
+	case TypeModifierOmitStackTrace:
+		return m.omitStackTrace
+	case TypeModifierTransparent:
+		return m.transparent
+	case TypeModifierOmitCauseMessage:
+		return m.omitCauseMessage
+	}
+
+	for _, registered := range m.registered {
+		if registered == modifier {
+			return true
+		}
+	}
+	return false
+}
+
 func (typeModifiers) ReplaceWith(new modifiers) modifiers {
 	panic("attempt to modify type modifiers the second time")
 }
@@ -92,6 +187,14 @@ func (m inheritedModifiers) Transparent() bool {
 	return m.parent.Transparent() || m.override.Transparent()
 }
 
+func (m inheritedModifiers) OmitCauseMessage() bool {
+	return m.parent.OmitCauseMessage() || m.override.OmitCauseMessage()
+}
+
+func (m inheritedModifiers) Has(modifier TypeModifier) bool {
+	return m.parent.Has(modifier) || m.override.Has(modifier)
+}
+
 func (m inheritedModifiers) ReplaceWith(new modifiers) modifiers {
 	m.override = new
 	return m
@@ -16,6 +16,13 @@ var (
 	modifierTestErrorNoTraceChild         = modifierTestErrorNoTrace.NewSubtype("child")
 	modifierTestErrorTransparent          = modifierTestNamespaceTransparent.NewType("simple")
 	modifierTestErrorGrandchild           = modifierTestNamespaceTransparentChild.NewType("all").ApplyModifiers(TypeModifierOmitStackTrace)
+	modifierTestNamespaceOmitCause        = NewNamespace("modifierOmitCause").ApplyModifiers(TypeModifierOmitCauseMessage)
+	modifierTestErrorOmitCause            = modifierTestNamespaceOmitCause.NewType("public")
+	modifierTestErrorOmitCauseChild       = modifierTestNamespace.NewType("public").ApplyModifiers(TypeModifierOmitCauseMessage).NewSubtype("child")
+	modifierTestAudited                   = RegisterTypeModifier("Audited")
+	modifierTestNamespaceAudited          = NewNamespace("modifierAudited").ApplyModifiers(modifierTestAudited)
+	modifierTestNamespaceAuditedChild     = modifierTestNamespaceAudited.NewSubNamespace("child").ApplyModifiers(TypeModifierOmitStackTrace)
+	modifierTestErrorAudited              = modifierTestNamespaceAuditedChild.NewType("simple")
 )
 
 func TestTypeModifier(t *testing.T) {
@@ -53,3 +60,64 @@ func TestTypeModifierInheritance(t *testing.T) {
 		require.NotContains(t, output, "errorx/modifier_test.go")
 	})
 }
+
+func TestTypeModifierOmitCauseMessage(t *testing.T) {
+	t.Run("Wrap", func(t *testing.T) {
+		err := modifierTestErrorOmitCause.Wrap(AssertionFailed.New("internal"), "failed")
+		require.Equal(t, "modifierOmitCause.public: failed", err.Error())
+		require.Equal(t, "modifierOmitCause.public: failed", fmt.Sprintf("%v", err))
+		require.Equal(t, "modifierOmitCause.public: failed", fmt.Sprintf("%s", err))
+		require.Contains(t, fmt.Sprintf("%+v", err), "modifierOmitCause.public: failed, cause: common.assertion_failed: internal")
+		require.Equal(t, "common.assertion_failed: internal", err.Cause().Error())
+	})
+
+	t.Run("Inheritance", func(t *testing.T) {
+		err := modifierTestErrorOmitCauseChild.Wrap(AssertionFailed.New("internal"), "failed")
+		require.Equal(t, "modifier.public.child: failed", err.Error())
+	})
+
+	t.Run("Decorated", func(t *testing.T) {
+		err := Decorate(modifierTestErrorOmitCause.Wrap(fmt.Errorf("internal"), "failed"), "decorated")
+		require.Equal(t, "decorated, cause: modifierOmitCause.public: failed", err.Error())
+		require.Contains(t, fmt.Sprintf("%+v", err), "decorated, cause: modifierOmitCause.public: failed, cause: internal")
+	})
+
+	t.Run("Transparent", func(t *testing.T) {
+		err := NewErrorBuilder(modifierTestErrorOmitCause).WithCause(AssertionFailed.New("internal")).Transparent().Create()
+		require.Equal(t, "common.assertion_failed: internal", err.Error())
+	})
+
+	t.Run("NoCause", func(t *testing.T) {
+		err := modifierTestErrorOmitCause.New("failed")
+		require.Equal(t, "modifierOmitCause.public: failed", err.Error())
+	})
+}
+
+func TestTypeModifierIntrospection(t *testing.T) {
+	t.Run("Builtin", func(t *testing.T) {
+		require.Empty(t, modifierTestError.Modifiers())
+		require.Equal(t, []TypeModifier{TypeModifierOmitStackTrace}, modifierTestErrorNoTraceChild.Modifiers())
+		require.Equal(t, []TypeModifier{TypeModifierTransparent, TypeModifierOmitStackTrace}, modifierTestErrorGrandchild.Modifiers())
+		require.Equal(t, []TypeModifier{TypeModifierTransparent}, modifierTestNamespaceTransparentChild.Modifiers())
+		require.Empty(t, modifierTestNamespace.Modifiers())
+		require.True(t, modifierTestErrorTransparent.HasModifier(TypeModifierTransparent))
+		require.False(t, modifierTestErrorTransparent.HasModifier(TypeModifierOmitStackTrace))
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		require.Equal(t, []TypeModifier{modifierTestAudited}, modifierTestNamespaceAudited.Modifiers())
+		require.Equal(t, []TypeModifier{TypeModifierOmitStackTrace, modifierTestAudited}, modifierTestErrorAudited.Modifiers())
+		require.True(t, modifierTestErrorAudited.HasModifier(modifierTestAudited))
+		require.True(t, modifierTestErrorAudited.NewSubtype("child").HasModifier(modifierTestAudited))
+		require.False(t, modifierTestError.HasModifier(modifierTestAudited))
+		require.NotEqual(t, modifierTestAudited, RegisterTypeModifier("Audited"))
+	})
+
+	t.Run("String", func(t *testing.T) {
+		require.Equal(t, "Transparent", TypeModifierTransparent.String())
+		require.Equal(t, "OmitStackTrace", TypeModifierOmitStackTrace.String())
+		require.Equal(t, "OmitCauseMessage", TypeModifierOmitCauseMessage.String())
+		require.Equal(t, "Audited", modifierTestAudited.String())
+		require.Equal(t, "TypeModifier(0)", TypeModifier(0).String())
+	})
+}
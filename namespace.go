@@ -10,6 +10,7 @@ import "fmt"
 // 		namespace.sub_namespace.type.subtype
 //
 type Namespace struct {
+	owner     *Registry
 	parent    *Namespace
 	id        uint64
 	name      string
@@ -24,24 +25,36 @@ type NamespaceKey struct {
 
 // NewNamespace defines a namespace with a name and, optionally, a number of inheritable traits.
 func NewNamespace(name string, traits ...Trait) Namespace {
-	namespace := newNamespace(nil, name, traits...)
-	globalRegistry.registerNamespace(namespace)
-	return namespace
+	return globalRegistry.NewNamespace(name, traits...)
 }
 
 // NewSubNamespace defines a child namespace that inherits all that is defined for a parent and, optionally, adds some more.
 func (n Namespace) NewSubNamespace(name string, traits ...Trait) Namespace {
-	namespace := newNamespace(&n, name, traits...)
-	globalRegistry.registerNamespace(namespace)
+	namespace := newNamespace(n.registry(), &n, name, traits...)
+	n.registry().registerNamespace(namespace)
 	return namespace
 }
 
 // ApplyModifiers makes a one-time modification of defaults in error creation.
 func (n Namespace) ApplyModifiers(modifiers ...TypeModifier) Namespace {
 	n.modifiers = n.modifiers.ReplaceWith(newTypeModifiers(modifiers...))
+	n.registry().modifyNamespace(n)
 	return n
 }
 
+// Modifiers returns the modifiers in effect for this namespace, both applied to it and inherited from a parent namespace.
+func (n Namespace) Modifiers() []TypeModifier {
+	if n.modifiers == nil {
+		return nil
+	}
+	return effectiveModifiers(n.modifiers)
+}
+
+// HasModifier checks if a modifier is in effect for this namespace, see Modifiers.
+func (n Namespace) HasModifier(modifier TypeModifier) bool {
+	return n.modifiers != nil && n.modifiers.Has(modifier)
+}
+
 // NewType creates a new type within a namespace  that inherits all that is defined for namespace and, optionally, adds some more.
 func (n Namespace) NewType(typeName string, traits ...Trait) *Type {
 	return NewType(n, typeName, traits...)
@@ -85,6 +98,26 @@ func (n Namespace) Parent() *Namespace {
 	return n.parent
 }
 
+// SubNamespaces returns the immediate child namespaces, in order of registration.
+// Along with Types() and Type.Subtypes(), it may be used to walk the tree of registered namespaces and types.
+func (n Namespace) SubNamespaces() []Namespace {
+	return n.registry().subNamespaces(n)
+}
+
+// Types returns the error types defined directly within a namespace, in order of registration.
+// Subtypes and types of sub-namespaces are not included.
+func (n Namespace) Types() []*Type {
+	return n.registry().namespaceTypes(n)
+}
+
+// registry returns a registry this namespace belongs to, which is the process-wide one for a zero value, such as returned by a failed lookup
+func (n Namespace) registry() *Registry {
+	if n.owner == nil {
+		return globalRegistry
+	}
+	return n.owner
+}
+
 func (n Namespace) collectTraits() map[Trait]bool {
 	result := make(map[Trait]bool)
 	namespace := &n
@@ -99,7 +132,7 @@ func (n Namespace) collectTraits() map[Trait]bool {
 	return result
 }
 
-func newNamespace(parent *Namespace, name string, traits ...Trait) Namespace {
+func newNamespace(registry *Registry, parent *Namespace, name string, traits ...Trait) Namespace {
 	createName := func() string {
 		if parent == nil {
 			return name
@@ -115,6 +148,7 @@ func newNamespace(parent *Namespace, name string, traits ...Trait) Namespace {
 	}
 
 	namespace := Namespace{
+		owner:     registry,
 		id:        nextInternalID(),
 		parent:    parent,
 		name:      createName(),
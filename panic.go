@@ -1,6 +1,9 @@
 package errorx
 
-import "fmt"
+import (
+	"fmt"
+	"runtime"
+)
 
 // Panic is an alternative to the built-in panic call.
 // When calling panic as a reaction to error, prefer this function over vanilla panic().
@@ -29,6 +32,9 @@ func Panic(err error) error {
 // More importantly, it allows for greater composability,
 // if ever there is a need to recover from panic and pass the error information forwards in its proper form.
 //
+// A runtime fault, such as nil dereference or index out of range, is recovered as an error of RuntimePanic type.
+// It retains the original runtime error text and the stack trace of the panic site.
+//
 // Note that panic is not a proper means to report errors,
 // so this mechanism should never be used where a error based control flow is at all possible.
 func ErrorFromPanic(recoverResult interface{}) (error, bool) {
@@ -41,9 +47,63 @@ func ErrorFromPanic(recoverResult interface{}) (error, bool) {
 		return wrapper.inner, true
 	}
 
+	if runtimeErr, ok := err.(runtime.Error); ok {
+		return newRuntimePanicError(runtimeErr), true
+	}
+
 	return err, true
 }
 
+// Recover transforms a panic, if there is one, into an error of RecoveredPanic type and stores it into the provided error variable.
+// It must be called directly by a deferred statement, typically along with a named error result:
+//
+//	func process() (err error) {
+//		defer errorx.Recover(&err)
+//		...
+//	}
+//
+// Any recovered value is accepted, not only an error: panic("boom") results in an error with "boom" message.
+// A runtime fault, such as nil dereference or index out of range, results in an error of RuntimePanic type.
+// If the recovered value is an error, it becomes the cause of the resulting error.
+// The stack trace of the result is collected at the panic site, and the stack trace of the original error,
+// if there is one (which is true for errorx.Panic() of an errorx error), is preserved as well.
+// If there is no panic, the error variable is left intact.
+func Recover(err *error) {
+	if r := recover(); r != nil {
+		*err = newRecoveredPanicError(r)
+	}
+}
+
+// Try calls a function and returns its result, or an error of RecoveredPanic type if the function panics.
+// See Recover() for details on how a recovered value is transformed into an error.
+func Try(fn func() error) (err error) {
+	defer Recover(&err)
+	return fn()
+}
+
+func newRecoveredPanicError(recoverResult interface{}) *Error {
+	builder := NewErrorBuilder(RecoveredPanic)
+	switch cause := recoverResult.(type) {
+	case *panicErrorWrapper:
+		// the wrapper layer only serves to preserve the stack trace, which is collected anew at this point
+		builder = builder.WithCause(Cast(cause.inner).Cause()).EnhanceStackTrace()
+	case runtime.Error:
+		builder = NewErrorBuilder(RuntimePanic).WithCause(cause).EnhanceStackTrace()
+	case error:
+		builder = builder.WithCause(cause).EnhanceStackTrace()
+	default:
+		builder = builder.WithConditionallyFormattedMessage("%v", recoverResult)
+	}
+
+	return builder.Create()
+}
+
+func newRuntimePanicError(err runtime.Error) *Error {
+	return NewErrorBuilder(RuntimePanic).
+		WithCause(err).
+		Create()
+}
+
 func newPanicErrorWrapper(err error) *panicErrorWrapper {
 	return &panicErrorWrapper{
 		inner: NewErrorBuilder(panicPayloadWrap).
@@ -177,3 +177,146 @@ func mischiefAsPanic() {
 func mischiefProper() error {
 	return ExternalError.New("mischief")
 }
+
+func TestRecover(t *testing.T) {
+	t.Run("NoPanic", func(t *testing.T) {
+		err := Try(funcWithErr)
+		require.True(t, IsOfType(err, testType))
+
+		err = Try(func() error { return nil })
+		require.NoError(t, err)
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		err := Try(func() error { panic("boom") })
+		require.True(t, IsOfType(err, RecoveredPanic))
+		require.Equal(t, "common.internal_error.panic: boom", err.Error())
+
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "TestRecover", output)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		err := Try(func() error {
+			funcWithBadPanic()
+			return nil
+		})
+		require.True(t, IsOfType(err, RecoveredPanic))
+
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "awful", output)
+		require.Contains(t, output, "errorx.funcWithBadPanic()", output)
+	})
+
+	t.Run("Errorx", func(t *testing.T) {
+		err := Try(func() error {
+			Panic(funcWithErr())
+			return nil
+		})
+		require.True(t, IsOfType(err, RecoveredPanic))
+		require.False(t, IsOfType(err, testType))
+		require.Equal(t, "common.internal_error.panic: foo.bar: bad", err.Error())
+
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "errorx.funcWithErr()", output)
+		require.Contains(t, output, "TestRecover", output)
+	})
+
+	t.Run("NamedResult", func(t *testing.T) {
+		fn := func() (err error) {
+			defer Recover(&err)
+			err = testType.New("overwritten")
+			panic("boom")
+		}
+
+		err := fn()
+		require.True(t, IsOfType(err, RecoveredPanic))
+		require.NotContains(t, err.Error(), "overwritten")
+	})
+}
+
+func TestRecoverRuntimeFault(t *testing.T) {
+	t.Run("NilDereference", func(t *testing.T) {
+		err := Try(func() error {
+			funcWithNilDereference()
+			return nil
+		})
+		require.True(t, IsOfType(err, RuntimePanic))
+		require.True(t, IsOfType(err, RecoveredPanic))
+		require.True(t, IsRuntimeFault(err))
+		require.Contains(t, err.Error(), "nil pointer dereference")
+
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "errorx.funcWithNilDereference()", output)
+	})
+
+	t.Run("IndexOutOfRange", func(t *testing.T) {
+		err := Try(func() error {
+			funcWithIndexOutOfRange()
+			return nil
+		})
+		require.True(t, IsOfType(err, RuntimePanic))
+		require.True(t, IsRuntimeFault(err))
+		require.Contains(t, err.Error(), "index out of range")
+
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "errorx.funcWithIndexOutOfRange()", output)
+	})
+
+	t.Run("ClosedChannel", func(t *testing.T) {
+		err := Try(func() error {
+			funcWithClosedChannelSend()
+			return nil
+		})
+		require.True(t, IsOfType(err, RuntimePanic))
+		require.True(t, IsRuntimeFault(err))
+		require.Contains(t, err.Error(), "send on closed channel")
+	})
+
+	t.Run("ExplicitPanic", func(t *testing.T) {
+		err := Try(func() error {
+			Panic(funcWithErr())
+			return nil
+		})
+		require.True(t, IsOfType(err, RecoveredPanic))
+		require.False(t, IsOfType(err, RuntimePanic))
+		require.False(t, IsRuntimeFault(err))
+	})
+
+	t.Run("ErrorFromPanic", func(t *testing.T) {
+		var err error
+		func() {
+			defer func() {
+				var ok bool
+				err, ok = ErrorFromPanic(recover())
+				require.True(t, ok)
+			}()
+
+			funcWithIndexOutOfRange()
+		}()
+
+		require.True(t, IsOfType(err, RuntimePanic))
+		require.True(t, IsRuntimeFault(err))
+		require.Contains(t, err.Error(), "common.internal_error.panic.runtime: runtime error: index out of range")
+
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "errorx.funcWithIndexOutOfRange()", output)
+	})
+}
+
+func funcWithNilDereference() {
+	var err *Error
+	_ = err.message
+}
+
+func funcWithIndexOutOfRange() {
+	var values []int
+	index := 5
+	_ = values[index]
+}
+
+func funcWithClosedChannelSend() {
+	ch := make(chan int, 1)
+	close(ch)
+	ch <- 1
+}
@@ -2,31 +2,69 @@ package errorx
 
 import (
 	"context"
+	"sync/atomic"
+	"unsafe"
 )
 
 // Property is a key to a dynamic property of an error.
 // Property value belongs to an error instance only, never inherited from a type.
-// Property visibility is hindered by Wrap, preserved by Decorate.
+// Property visibility is hindered by Wrap, preserved by Decorate, unless a property is sticky, see PropertyModifierSticky.
 type Property struct {
 	*property // Property is compared by this pointer.
 }
 
 type property struct {
+	id        uint64
 	label     string
 	printable bool
+	sensitive bool
+	sticky    bool
+	internal  bool
 }
 
+// PropertyModifier is a way to change a default behaviour for a property.
+type PropertyModifier int
+
+const (
+	// PropertyModifierSensitive is a property modifier; a value of a property with such modifier is redacted in all output,
+	// see InitializeRedactionPolicy and RevealSensitiveProperties
+	PropertyModifierSensitive PropertyModifier = 1
+	// PropertyModifierSticky is a property modifier; a property with such modifier remains visible through an opaque wrap,
+	// which suits diagnostic data such as request or trace IDs, as opposed to semantic data which an opaque wrap is meant to hide
+	PropertyModifierSticky PropertyModifier = 2
+)
+
 // RegisterProperty registers a new property key.
 // It is used both to add a dynamic property to an error instance, and to extract property value back from error.
-func RegisterProperty(label string) Property {
-	return newProperty(label, false)
+func RegisterProperty(label string, modifiers ...PropertyModifier) Property {
+	return registerProperty(globalRegistry, label, false, modifiers...)
 }
 
 // RegisterPrintableProperty registers a new property key for informational value.
 // It is used both to add a dynamic property to an error instance, and to extract property value back from error.
 // Printable property will be included in Error() message, both name and value.
-func RegisterPrintableProperty(label string) Property {
-	return newProperty(label, true)
+func RegisterPrintableProperty(label string, modifiers ...PropertyModifier) Property {
+	return registerProperty(globalRegistry, label, true, modifiers...)
+}
+
+// Label returns a label a property was registered with.
+func (p Property) Label() string {
+	return p.label
+}
+
+// Printable checks if a property is included in Error() message, see RegisterPrintableProperty.
+func (p Property) Printable() bool {
+	return p.printable
+}
+
+// Sensitive checks if a property value is redacted in the output, see PropertyModifierSensitive.
+func (p Property) Sensitive() bool {
+	return p.sensitive
+}
+
+// Sticky checks if a property remains visible through an opaque wrap, see PropertyModifierSticky.
+func (p Property) Sticky() bool {
+	return p.sticky
 }
 
 // PropertyContext is a context property, value is expected to be of context.Context type.
@@ -75,15 +113,55 @@ func ExtractProperty(err error, key Property) (interface{}, bool) {
 	return typedErr.Property(key)
 }
 
+// ExtractProperties returns all the properties of an error with their values, see Error.VisitProperties.
+// The result is empty for a non-errorx error.
+func ExtractProperties(err error) map[Property]interface{} {
+	typedErr := Cast(err)
+	if typedErr == nil {
+		return map[Property]interface{}{}
+	}
+
+	return typedErr.Properties()
+}
+
 var (
-	propertyContext    = RegisterProperty("ctx")
-	propertyPayload    = RegisterProperty("payload")
-	propertyUnderlying = RegisterProperty("underlying")
+	propertyContext = RegisterProperty("ctx")
+	propertyPayload = RegisterProperty("payload")
+	// internal properties, not registered for public use
+	propertyUnderlying      = newInternalProperty("underlying")
+	propertyPublicMessage   = newInternalProperty("public_message")
+	propertyMessageTemplate = newInternalProperty("message_template")
+	propertyTraits          = newInternalProperty("traits")
+	propertyMaskedTraits    = newInternalProperty("masked_traits")
 )
 
+func registerProperty(registry *Registry, label string, printable bool, modifiers ...PropertyModifier) Property {
+	p := newProperty(label, printable)
+	for _, modifier := range modifiers {
+		switch modifier {
+		case PropertyModifierSensitive:
+			p.sensitive = true
+		case PropertyModifierSticky:
+			p.sticky = true
+		}
+	}
+
+	registry.registerProperty(p)
+	return p
+}
+
+func newInternalProperty(label string) Property {
+	p := newProperty(label, false)
+	p.internal = true
+	return p
+}
+
+var propertyCount uint64
+
 func newProperty(label string, printable bool) Property {
 	p := Property{
 		&property{
+			id:        atomic.AddUint64(&propertyCount, 1),
 			label:     label,
 			printable: printable,
 		},
@@ -93,17 +171,30 @@ func newProperty(label string, printable bool) Property {
 
 // propertyMap represents map of properties.
 // Compared to builtin type, it uses less allocations and reallocations on copy.
-// It is implemented as a simple linked list.
+// It is implemented as a linked list, so that adding a property copies nothing.
+// To keep lookups fast with many properties, an error with at least propertyIndexThreshold of them visible
+// builds an index of all those properties, ones of the transparent causes included, upon the first lookup, see Error.Property.
 type propertyMap struct {
 	p     Property
 	value interface{}
 	next  *propertyMap
+	count int
+	index unsafe.Pointer // *propertyIndex
 }
 
+// propertyIndexThreshold is a number of visible properties an error must have to be indexed;
+// with fewer of them, a linear scan is about as fast as an index, and indexing is not worth its cost
+const propertyIndexThreshold = 12
+
 func (pm *propertyMap) with(p Property, value interface{}) *propertyMap {
-	return &propertyMap{p: p, value: value, next: pm}
+	count := 1
+	if pm != nil {
+		count += pm.count
+	}
+	return &propertyMap{p: p, value: value, next: pm, count: count}
 }
 
+// get finds a value with a linear scan, which is the fastest way for a few properties, see lookup
 func (pm *propertyMap) get(p Property) (value interface{}, ok bool) {
 	for pm != nil {
 		if pm.p == p {
@@ -113,3 +204,99 @@ func (pm *propertyMap) get(p Property) (value interface{}, ok bool) {
 	}
 	return nil, false
 }
+
+// lookup finds a value among all the properties visible from an error which owns this map, building an index of them if needed.
+// An index never goes stale, as neither an error nor its properties change once created.
+func (pm *propertyMap) lookup(owner *Error, p Property) (value interface{}, ok bool) {
+	index := (*propertyIndex)(atomic.LoadPointer(&pm.index))
+	if index == nil {
+		// concurrent lookups may build an index more than once, which is harmless
+		index = owner.buildPropertyIndex()
+		atomic.StorePointer(&pm.index, unsafe.Pointer(index))
+	}
+	return index.get(p)
+}
+
+// visiblePropertyCount is a number of properties visible from an error, as far as it is known without a lookup:
+// a property set more than once is counted each time, and sticky properties behind an opaque wrap are not counted at all
+func (e *Error) visiblePropertyCount() int {
+	count := int(e.inheritedPropertyCount)
+	if e.properties != nil {
+		count += e.properties.count
+	}
+	return count
+}
+
+// buildPropertyIndex indexes the properties visible from an error, with the same visibility rules as with Property()
+func (e *Error) buildPropertyIndex() *propertyIndex {
+	count := 0
+	e.visiblePropertyNodes(func(*propertyMap) {
+		count++
+	})
+
+	index := newPropertyIndex(count)
+	e.visiblePropertyNodes(index.putIfAbsent)
+	return index
+}
+
+// visiblePropertyNodes lists the property nodes visible from an error, closest first, each property possibly more than once
+func (e *Error) visiblePropertyNodes(visitor func(node *propertyMap)) {
+	opaque := false
+	for cause := e; cause != nil; cause = Cast(cause.Cause()) {
+		for m := cause.properties; m != nil; m = m.next {
+			if !opaque || m.p.sticky {
+				visitor(m)
+			}
+		}
+
+		if !cause.transparent {
+			opaque = true
+		}
+	}
+}
+
+// propertyIndex is a hash table of nodes by property with open addressing.
+// It is several times faster than a builtin map with such small keys.
+type propertyIndex struct {
+	nodes []*propertyMap
+}
+
+func newPropertyIndex(capacity int) *propertyIndex {
+	size := 1
+	for size < 2*capacity {
+		size <<= 1
+	}
+	return &propertyIndex{nodes: make([]*propertyMap, size)}
+}
+
+// putIfAbsent adds a node unless there is already one for the same property, which is closer to the error
+func (idx *propertyIndex) putIfAbsent(node *propertyMap) {
+	mask := len(idx.nodes) - 1
+	for i := idx.slot(node.p); ; i = (i + 1) & mask {
+		if idx.nodes[i] == nil {
+			idx.nodes[i] = node
+			return
+		}
+		if idx.nodes[i].p == node.p {
+			return
+		}
+	}
+}
+
+func (idx *propertyIndex) get(p Property) (value interface{}, ok bool) {
+	mask := len(idx.nodes) - 1
+	for i := idx.slot(p); ; i = (i + 1) & mask {
+		node := idx.nodes[i]
+		if node == nil {
+			return nil, false
+		}
+		if node.p == p {
+			return node.value, true
+		}
+	}
+}
+
+func (idx *propertyIndex) slot(p Property) int {
+	// Fibonacci hashing spreads sequential ids over the table
+	return int((p.id*0x9E3779B97F4A7C15)>>32) & (len(idx.nodes) - 1)
+}
@@ -201,3 +201,146 @@ func BenchmarkGetProperty(b *testing.B) {
 		})
 	}
 }
+
+func TestVisitProperties(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		err := testType.New("test").WithProperty(testProperty0, 42).WithProperty(testInfoProperty2, "x")
+		require.Equal(t, map[Property]interface{}{testProperty0: 42, testInfoProperty2: "x"}, err.Properties())
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		err := testType.New("test").WithProperty(testProperty0, 1).WithProperty(testProperty1, 2)
+		err = Decorate(err, "decorated").WithProperty(testInfoProperty2, 3)
+
+		var labels []string
+		err.VisitProperties(func(p Property, value interface{}) bool {
+			labels = append(labels, p.Label())
+			return true
+		})
+		require.Equal(t, []string{"prop2", "test1", "test0"}, labels)
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		err := testType.New("test").WithProperty(testProperty0, 1).WithProperty(testProperty1, 2)
+
+		count := 0
+		err.VisitProperties(func(p Property, value interface{}) bool {
+			count++
+			return false
+		})
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("Shadowed", func(t *testing.T) {
+		err := testType.New("test").WithProperty(testProperty0, 1)
+		err = Decorate(err, "decorated").WithProperty(testProperty0, 2).WithProperty(testProperty0, 3)
+		require.Equal(t, map[Property]interface{}{testProperty0: 3}, err.Properties())
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		err := testType.New("test").WithProperty(testProperty0, 42)
+		err = testTypeBar1.Wrap(err, "wrapped").WithProperty(testProperty1, 1)
+		require.Equal(t, map[Property]interface{}{testProperty1: 1}, err.Properties())
+	})
+
+	t.Run("Internal", func(t *testing.T) {
+		err := testType.New("test").WithUnderlyingErrors(testType.New("other")).WithPublicMessage("public")
+		require.Empty(t, err.Properties())
+	})
+
+	t.Run("Sensitive", func(t *testing.T) {
+		sensitive := RegisterProperty("visit_sensitive", PropertyModifierSensitive)
+		err := testType.New("test").WithProperty(sensitive, "secret")
+		require.Equal(t, map[Property]interface{}{sensitive: "[redacted]"}, err.Properties())
+	})
+
+	t.Run("Helper", func(t *testing.T) {
+		require.Equal(t, map[Property]interface{}{testProperty0: 42}, ExtractProperties(testType.New("test").WithProperty(testProperty0, 42)))
+		require.Empty(t, ExtractProperties(fmt.Errorf("test")))
+	})
+}
+
+func TestManyProperties(t *testing.T) {
+	properties := make([]Property, 50)
+	for i := range properties {
+		properties[i] = RegisterProperty(fmt.Sprintf("many%d", i))
+	}
+
+	expected := make(map[Property]interface{})
+	err := testType.New("test")
+	for i := 0; i < 120; i++ {
+		p := properties[(i*7)%len(properties)]
+		err = err.WithProperty(p, i)
+		expected[p] = i
+
+		if i%40 == 39 {
+			err = Decorate(err, "decorated")
+		}
+	}
+
+	for _, p := range properties {
+		value, ok := err.Property(p)
+		require.True(t, ok)
+		require.Equal(t, expected[p], value)
+	}
+	require.Equal(t, expected, err.Properties())
+
+	_, ok := err.Property(testProperty0)
+	require.False(t, ok)
+
+	t.Run("Wrapped", func(t *testing.T) {
+		sticky := RegisterProperty("many_sticky", PropertyModifierSticky)
+		wrapped := testTypeBar1.Wrap(err.WithProperty(sticky, "inner"), "wrapped")
+		for i := 0; i < 2*propertyIndexThreshold; i++ {
+			wrapped = wrapped.WithProperty(properties[i], -i)
+		}
+		wrapped = Decorate(wrapped, "decorated").WithProperty(testProperty0, 42)
+
+		value, ok := wrapped.Property(sticky)
+		require.True(t, ok)
+		require.Equal(t, "inner", value)
+
+		value, ok = wrapped.Property(properties[1])
+		require.True(t, ok)
+		require.Equal(t, -1, value)
+
+		_, ok = wrapped.Property(properties[len(properties)-1])
+		require.False(t, ok)
+	})
+}
+
+func TestStickyProperty(t *testing.T) {
+	sticky := RegisterPrintableProperty("sticky", PropertyModifierSticky)
+	require.True(t, sticky.Sticky())
+	require.False(t, testProperty0.Sticky())
+
+	err := testType.New("test").WithProperty(sticky, "request").WithProperty(testProperty0, 42)
+	err = testTypeBar1.Wrap(Decorate(err, "decorated"), "wrapped")
+	err = testTypeBar2.Wrap(err, "wrapped again")
+
+	t.Run("Property", func(t *testing.T) {
+		value, ok := err.Property(sticky)
+		require.True(t, ok)
+		require.Equal(t, "request", value)
+
+		_, ok = err.Property(testProperty0)
+		require.False(t, ok)
+	})
+
+	t.Run("Shadowed", func(t *testing.T) {
+		err := testTypeBar1.Wrap(err, "wrapped").WithProperty(sticky, "outer")
+		value, ok := err.Property(sticky)
+		require.True(t, ok)
+		require.Equal(t, "outer", value)
+		require.Equal(t, map[Property]interface{}{sticky: "outer"}, err.Properties())
+	})
+
+	t.Run("Enumeration", func(t *testing.T) {
+		require.Equal(t, map[Property]interface{}{sticky: "request"}, err.Properties())
+	})
+
+	t.Run("NonErrorx", func(t *testing.T) {
+		_, ok := testType.Wrap(fmt.Errorf("test"), "wrapped").Property(sticky)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,69 @@
+package errorx
+
+// GenericPublicMessage is a public message of an error which has none of its own, see PublicMessage.
+const GenericPublicMessage = "An error occurred."
+
+// WithPublicMessage sets a default public message for all errors of this type and its subtypes, unless those set their own.
+// A public message is meant for end users, as opposed to an error message, see PublicMessage.
+func (t *Type) WithPublicMessage(message string) *Type {
+	t.publicMessage = message
+	return t
+}
+
+// PublicMessage returns a default public message of this type or the closest supertype which has one, see WithPublicMessage.
+func (t *Type) PublicMessage() (string, bool) {
+	for current := t; current != nil; current = current.parent {
+		if current.publicMessage != "" {
+			return current.publicMessage, true
+		}
+	}
+
+	return "", false
+}
+
+// WithPublicMessage provides a public message for an error, see Error.PublicMessage.
+func (eb ErrorBuilder) WithPublicMessage(message string) ErrorBuilder {
+	eb.publicMessage = message
+	return eb
+}
+
+// WithPublicMessage sets a public message of an error instance, which is meant for end users, see PublicMessage.
+// If an error already contained another public message, it is overwritten.
+func (e *Error) WithPublicMessage(message string) *Error {
+	return e.WithProperty(propertyPublicMessage, message)
+}
+
+// PublicMessage returns a message meant for end users, which never includes the internal details such as an error message or a cause.
+//
+// A public message is looked up the chain of causes, layer by layer. A public message set for an instance is used first.
+// A transparent wrapper, such as by Decorate, passes a public message of its cause through.
+// An opaque wrapper, such as by Wrap, uses the default public message of its type if there is one, see Type.WithPublicMessage,
+// and otherwise passes the public message of its cause through as well; the same holds for an original error.
+// Non-errorx errors have no public message.
+func (e *Error) PublicMessage() (string, bool) {
+	for cause := e; cause != nil; cause = Cast(cause.Cause()) {
+		if message, ok := cause.properties.get(propertyPublicMessage); ok {
+			return message.(string), true
+		}
+
+		if !cause.transparent {
+			if message, ok := cause.errorType.PublicMessage(); ok {
+				return message, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// PublicMessage returns a message of an error meant for end users, see Error.PublicMessage.
+// An error which has none, non-errorx errors included, is presented with GenericPublicMessage.
+func PublicMessage(err error) string {
+	if typedErr := Cast(err); typedErr != nil {
+		if message, ok := typedErr.PublicMessage(); ok {
+			return message
+		}
+	}
+
+	return GenericPublicMessage
+}
@@ -0,0 +1,68 @@
+package errorx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	publicTestNamespace = NewNamespace("public")
+	publicTestType      = publicTestNamespace.NewType("type").WithPublicMessage("Type public")
+	publicTestSubtype   = publicTestType.NewSubtype("subtype")
+	publicTestPlain     = publicTestNamespace.NewType("plain")
+)
+
+func TestPublicMessage(t *testing.T) {
+	t.Run("Instance", func(t *testing.T) {
+		err := publicTestPlain.New("internal").WithPublicMessage("Instance public")
+		require.Equal(t, "Instance public", PublicMessage(err))
+		require.Equal(t, "public.plain: internal", err.Error())
+
+		err = NewErrorBuilder(publicTestType).WithConditionallyFormattedMessage("internal").WithPublicMessage("Builder public").Create()
+		require.Equal(t, "Builder public", PublicMessage(err))
+	})
+
+	t.Run("TypeDefault", func(t *testing.T) {
+		require.Equal(t, "Type public", PublicMessage(publicTestType.New("internal")))
+		require.Equal(t, "Type public", PublicMessage(publicTestSubtype.New("internal")))
+
+		message, ok := publicTestSubtype.PublicMessage()
+		require.True(t, ok)
+		require.Equal(t, "Type public", message)
+
+		_, ok = publicTestPlain.PublicMessage()
+		require.False(t, ok)
+	})
+
+	t.Run("Generic", func(t *testing.T) {
+		require.Equal(t, GenericPublicMessage, PublicMessage(publicTestPlain.New("internal")))
+		require.Equal(t, GenericPublicMessage, PublicMessage(errors.New("internal")))
+		require.Equal(t, GenericPublicMessage, PublicMessage(nil))
+	})
+
+	t.Run("Decorate", func(t *testing.T) {
+		err := publicTestPlain.New("internal").WithPublicMessage("Instance public")
+		require.Equal(t, "Instance public", PublicMessage(Decorate(err, "decorated")))
+		require.Equal(t, "Type public", PublicMessage(Decorate(publicTestType.New("internal"), "decorated")))
+		require.Equal(t, "Outer public", PublicMessage(Decorate(err, "decorated").WithPublicMessage("Outer public")))
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		err := publicTestPlain.New("internal").WithPublicMessage("Instance public")
+		require.Equal(t, "Instance public", PublicMessage(publicTestPlain.Wrap(err, "wrapped")))
+		require.Equal(t, "Type public", PublicMessage(publicTestType.Wrap(err, "wrapped")))
+		require.Equal(t, "Outer public", PublicMessage(publicTestType.Wrap(err, "wrapped").WithPublicMessage("Outer public")))
+		require.Equal(t, GenericPublicMessage, PublicMessage(publicTestPlain.Wrap(errors.New("internal"), "wrapped")))
+	})
+
+	t.Run("NotAProperty", func(t *testing.T) {
+		err := publicTestPlain.New("internal").WithPublicMessage("Instance public")
+		require.Equal(t, "Instance public", NewLocalizer().Localize(err, "en"))
+
+		l := NewLocalizer()
+		l.RegisterBundle(NewMessageBundle("en", map[string]string{"public.plain": "Template {public_message}"}))
+		require.Equal(t, "Template ", l.Localize(err, "en"))
+	})
+}
@@ -0,0 +1,79 @@
+package errorx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// RedactionPolicy is a user defined transformation of a sensitive property value for the output, see PropertyModifierSensitive.
+type RedactionPolicy func(p Property, value interface{}) string
+
+// RedactionPolicyMask replaces a value with a fixed placeholder, and is used by default.
+func RedactionPolicyMask(p Property, value interface{}) string {
+	return "[redacted]"
+}
+
+// RedactionPolicyHash replaces a value with a short hash of its text form, so that equal values may still be matched in logs.
+// NB: a hash of a value from a small set, such as a short number, is easily reversed.
+func RedactionPolicyHash(p Property, value interface{}) string {
+	sum := sha256.Sum256([]byte(fmt.Sprint(value)))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+// InitializeRedactionPolicy provides a policy to be used for sensitive property values in the output of all the errors,
+// that is, in Error(), formatting and localized messages alike.
+//
+// NB: error is returned if a policy was already set up.
+// Policy is changed nonetheless, the old one is returned along with an error.
+func InitializeRedactionPolicy(policy RedactionPolicy) (RedactionPolicy, error) {
+	redaction.mu.Lock()
+	defer redaction.mu.Unlock()
+
+	old := redaction.policy.Load().(RedactionPolicy)
+	redaction.policy.Store(policy)
+
+	if redaction.initialized {
+		return old, InitializationFailed.New("redaction policy was already set up")
+	}
+
+	redaction.initialized = true
+	return nil, nil
+}
+
+// RevealSensitiveProperties switches the output of sensitive property values as is, which is off by default.
+// It is only meant for local debugging.
+// Returns the previous setting.
+func RevealSensitiveProperties(reveal bool) bool {
+	value := int32(0)
+	if reveal {
+		value = 1
+	}
+
+	return atomic.SwapInt32(&redaction.revealed, value) != 0
+}
+
+var redaction = struct {
+	mu          *sync.Mutex
+	policy      *atomic.Value
+	initialized bool
+	revealed    int32
+}{
+	mu:     &sync.Mutex{},
+	policy: &atomic.Value{},
+}
+
+func init() {
+	redaction.policy.Store(RedactionPolicy(RedactionPolicyMask))
+}
+
+// redactedValue returns a property value fit for the output
+func redactedValue(p Property, value interface{}) interface{} {
+	if !p.sensitive || atomic.LoadInt32(&redaction.revealed) != 0 {
+		return value
+	}
+
+	return redaction.policy.Load().(RedactionPolicy)(p, value)
+}
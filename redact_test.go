@@ -0,0 +1,63 @@
+package errorx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	redactTestType      = NewNamespace("redact").NewType("type")
+	redactTestSensitive = RegisterPrintableProperty("token", PropertyModifierSensitive)
+	redactTestHidden    = RegisterProperty("password", PropertyModifierSensitive)
+	redactTestPlain     = RegisterPrintableProperty("user")
+)
+
+func TestSensitiveProperty(t *testing.T) {
+	require.True(t, redactTestSensitive.Sensitive())
+	require.True(t, redactTestSensitive.Printable())
+	require.False(t, redactTestHidden.Printable())
+	require.False(t, redactTestPlain.Sensitive())
+
+	err := redactTestType.New("failed").
+		WithProperty(redactTestSensitive, "secret").
+		WithProperty(redactTestHidden, "password").
+		WithProperty(redactTestPlain, "john")
+
+	t.Run("Property", func(t *testing.T) {
+		value, ok := err.Property(redactTestSensitive)
+		require.True(t, ok)
+		require.Equal(t, "secret", value)
+	})
+
+	t.Run("Mask", func(t *testing.T) {
+		require.Equal(t, "redact.type: failed {user: john, token: [redacted]}", err.Error())
+		require.NotContains(t, fmt.Sprintf("%+v", Decorate(err, "decorated")), "secret")
+	})
+
+	t.Run("Localize", func(t *testing.T) {
+		l := NewLocalizer()
+		l.RegisterBundle(NewMessageBundle("en", map[string]string{"redact.type": "Token {token} of {user}"}))
+		require.Equal(t, "Token [redacted] of john", l.Localize(err, "en"))
+	})
+
+	t.Run("Reveal", func(t *testing.T) {
+		previous := RevealSensitiveProperties(true)
+		defer RevealSensitiveProperties(previous)
+
+		require.Equal(t, "redact.type: failed {user: john, token: secret}", err.Error())
+	})
+
+	t.Run("Policy", func(t *testing.T) {
+		_, _ = InitializeRedactionPolicy(RedactionPolicyHash)
+		defer func() {
+			old, err := InitializeRedactionPolicy(RedactionPolicyMask)
+			require.Error(t, err)
+			require.NotNil(t, old)
+		}()
+
+		require.Equal(t, "redact.type: failed {user: john, token: sha256:2bb80d537b1da3e3}", err.Error())
+		require.Equal(t, RedactionPolicyHash(redactTestSensitive, "secret"), RedactionPolicyHash(redactTestHidden, "secret"))
+	})
+}
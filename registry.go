@@ -1,10 +1,18 @@
 package errorx
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 // TypeSubscriber is an interface to receive callbacks on the registered error namespaces and types.
 // This may be used to create a user-defined registry, for example, to check if all type names are unique.
-// ISSUE: if .ApplyModifiers is called for a type/namespace, callback still receives a value without those modifiers.
+// Note that a callback receives a value as it is at the moment of creation, that is, without modifiers,
+// as .ApplyModifiers is called for a type/namespace afterwards; see ModifierSubscriber.
+//
+// Callbacks are called outside of the registry lock, so a subscriber may declare namespaces and types or call registry functions from a callback.
+// Callbacks are called in a goroutine which declares a namespace or a type, and so a subscriber must synchronize its own state
+// if those are declared concurrently, e.g. in tests.
 type TypeSubscriber interface {
 	// OnNamespaceCreated is called exactly once for each namespace
 	OnNamespaceCreated(namespace Namespace)
@@ -12,50 +20,333 @@ type TypeSubscriber interface {
 	OnTypeCreated(t *Type)
 }
 
+// ModifierSubscriber is an optional interface for a TypeSubscriber to also receive callbacks on modifiers applied and codes assigned.
+// If a namespace or a type was modified before the subscription, the creation callback receives an already modified value.
+type ModifierSubscriber interface {
+	// OnNamespaceModified is called each time .ApplyModifiers is called for a namespace, with the modified value
+	OnNamespaceModified(namespace Namespace)
+	// OnTypeModified is called each time .ApplyModifiers or .WithCode is called for a type
+	OnTypeModified(t *Type)
+}
+
 // RegisterTypeSubscriber adds a new TypeSubscriber.
 // A subscriber is guaranteed to receive callbacks for all namespaces and types.
 // If a type is already registered at the moment of subscription, a callback for this type is called immediately.
+// If a subscriber also implements ModifierSubscriber, it receives the modifier callbacks as well.
 func RegisterTypeSubscriber(s TypeSubscriber) {
-	globalRegistry.registerTypeSubscriber(s)
+	globalRegistry.RegisterTypeSubscriber(s)
+}
+
+// UnregisterTypeSubscriber cancels all the subscriptions of a TypeSubscriber, see RegisterTypeSubscriber.
+// No callbacks are started after it returns, though a callback already in progress in another goroutine may still complete.
+// A subscriber is found by equality, so it panics if a subscriber is of a non-comparable type, while a pointer is always fine.
+func UnregisterTypeSubscriber(s TypeSubscriber) {
+	globalRegistry.UnregisterTypeSubscriber(s)
+}
+
+// RegisteredNamespaces returns all the namespaces registered so far, in order of registration.
+func RegisteredNamespaces() []Namespace {
+	return globalRegistry.Namespaces()
+}
+
+// RegisteredTypes returns all the error types registered so far, in order of registration.
+func RegisteredTypes() []*Type {
+	return globalRegistry.Types()
 }
 
-type registry struct {
+// RegisteredTraits returns all the traits registered so far, in order of registration.
+func RegisteredTraits() []Trait {
+	return globalRegistry.Traits()
+}
+
+// RegisteredProperties returns all the property keys registered so far, in order of registration.
+func RegisteredProperties() []Property {
+	return globalRegistry.Properties()
+}
+
+// LookupNamespace finds a namespace by its full name.
+// As the name is not presumed to be unique, the first namespace registered with this name is returned.
+func LookupNamespace(fullName string) (Namespace, bool) {
+	return globalRegistry.LookupNamespace(fullName)
+}
+
+// LookupType finds an error type by its full name.
+// As the name is not presumed to be unique, the first type registered with this name is returned.
+func LookupType(fullName string) (*Type, bool) {
+	return globalRegistry.LookupType(fullName)
+}
+
+// Registry is a collection of namespaces, types, traits and properties.
+// All of those are registered in a process-wide registry by default,
+// while an isolated registry may be created with NewRegistry, which is mostly useful in tests.
+// The namespaces, types, traits and properties declared via Registry methods belong to that registry only:
+// they are neither visible in the process-wide registry, nor reported to its subscribers, nor checked for duplicate names.
+// All the sub-namespaces and types of a namespace belong to the same registry as the namespace.
+type Registry struct {
 	mu              sync.Mutex
-	subscribers     []TypeSubscriber
+	subscribers     []*typeSubscription
 	knownNamespaces []Namespace
 	knownTypes      []*Type
+	knownTraits     []Trait
+	knownProperties []Property
+	codes           map[string]*Type
+	strictNames     bool
+}
+
+var globalRegistry = NewRegistry()
+
+// NewRegistry creates a new isolated registry.
+// An isolated registry need not be disposed of explicitly: once not referenced, it is dropped along with its contents.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// NewNamespace defines a namespace within this registry, see errorx.NewNamespace.
+func (r *Registry) NewNamespace(name string, traits ...Trait) Namespace {
+	namespace := newNamespace(r, nil, name, traits...)
+	r.registerNamespace(namespace)
+	return namespace
+}
+
+// RegisterTrait declares a new distinct trait within this registry, see errorx.RegisterTrait.
+func (r *Registry) RegisterTrait(label string, options ...TraitOption) Trait {
+	return newTrait(r, label, options...)
+}
+
+// RegisterProperty registers a new property key within this registry, see errorx.RegisterProperty.
+// A property key works with any error, while it is only listed by this registry, see Properties.
+func (r *Registry) RegisterProperty(label string, modifiers ...PropertyModifier) Property {
+	return registerProperty(r, label, false, modifiers...)
 }
 
-var globalRegistry = &registry{}
+// RegisterPrintableProperty registers a new printable property key within this registry, see errorx.RegisterPrintableProperty.
+func (r *Registry) RegisterPrintableProperty(label string, modifiers ...PropertyModifier) Property {
+	return registerProperty(r, label, true, modifiers...)
+}
+
+// RegisterTypeSubscriber adds a new TypeSubscriber to this registry, see errorx.RegisterTypeSubscriber.
+func (r *Registry) RegisterTypeSubscriber(s TypeSubscriber) {
+	subscription := &typeSubscription{subscriber: s}
 
-func (r *registry) registerNamespace(namespace Namespace) {
+	r.mu.Lock()
+	namespaces := append([]Namespace(nil), r.knownNamespaces...)
+	types := append([]*Type(nil), r.knownTypes...)
+	r.subscribers = append(r.subscribers, subscription)
+	r.mu.Unlock()
+
+	for _, ns := range namespaces {
+		subscription.onNamespaceCreated(ns)
+	}
+
+	for _, t := range types {
+		subscription.onTypeCreated(t)
+	}
+}
+
+// UnregisterTypeSubscriber cancels all the subscriptions of a TypeSubscriber to this registry, see errorx.UnregisterTypeSubscriber.
+func (r *Registry) UnregisterTypeSubscriber(s TypeSubscriber) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.knownNamespaces = append(r.knownNamespaces, namespace)
-	for _, s := range r.subscribers {
-		s.OnNamespaceCreated(namespace)
+	subscribers := make([]*typeSubscription, 0, len(r.subscribers))
+	for _, subscription := range r.subscribers {
+		if subscription.subscriber == s {
+			subscription.cancel()
+		} else {
+			subscribers = append(subscribers, subscription)
+		}
 	}
+
+	r.subscribers = subscribers
+}
+
+// Namespaces returns all the namespaces registered so far in this registry, in order of registration.
+func (r *Registry) Namespaces() []Namespace {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Namespace(nil), r.knownNamespaces...)
+}
+
+// Types returns all the error types registered so far in this registry, in order of registration.
+func (r *Registry) Types() []*Type {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*Type(nil), r.knownTypes...)
+}
+
+// Traits returns all the traits registered so far in this registry, in order of registration.
+func (r *Registry) Traits() []Trait {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Trait(nil), r.knownTraits...)
 }
 
-func (r *registry) registerType(t *Type) {
+// Properties returns all the property keys registered so far in this registry, in order of registration.
+func (r *Registry) Properties() []Property {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	return append([]Property(nil), r.knownProperties...)
+}
+
+// LookupNamespace finds a namespace in this registry by its full name, see errorx.LookupNamespace.
+func (r *Registry) LookupNamespace(fullName string) (Namespace, bool) {
+	for _, namespace := range r.Namespaces() {
+		if namespace.FullName() == fullName {
+			return namespace, true
+		}
+	}
+
+	return Namespace{}, false
+}
+
+// LookupType finds an error type in this registry by its full name, see errorx.LookupType.
+func (r *Registry) LookupType(fullName string) (*Type, bool) {
+	for _, t := range r.Types() {
+		if t.FullName() == fullName {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
+
+func (r *Registry) registerNamespace(namespace Namespace) {
+	r.mu.Lock()
+	r.checkNamespaceNameLocked(namespace)
+	r.knownNamespaces = append(r.knownNamespaces, namespace)
+	subscribers := r.subscribers
+	r.mu.Unlock()
+
+	for _, s := range subscribers {
+		s.onNamespaceCreated(namespace)
+	}
+}
+
+func (r *Registry) registerType(t *Type) {
+	r.mu.Lock()
+	r.checkTypeNameLocked(t)
 	r.knownTypes = append(r.knownTypes, t)
-	for _, s := range r.subscribers {
-		s.OnTypeCreated(t)
+	subscribers := r.subscribers
+	r.mu.Unlock()
+
+	for _, s := range subscribers {
+		s.onTypeCreated(t)
 	}
 }
 
-func (r *registry) registerTypeSubscriber(s TypeSubscriber) {
-	for _, ns := range r.knownNamespaces {
-		s.OnNamespaceCreated(ns)
+func (r *Registry) modifyNamespace(namespace Namespace) {
+	r.mu.Lock()
+	for i := range r.knownNamespaces {
+		if r.knownNamespaces[i].Key() == namespace.Key() {
+			r.knownNamespaces[i] = namespace
+		}
+	}
+	subscribers := r.subscribers
+	r.mu.Unlock()
+
+	for _, s := range subscribers {
+		s.onNamespaceModified(namespace)
+	}
+}
+
+func (r *Registry) modifyType(t *Type, modifiers modifiers) {
+	r.mu.Lock()
+	t.modifiers = modifiers
+	subscribers := r.subscribers
+	r.mu.Unlock()
+
+	for _, s := range subscribers {
+		s.onTypeModified(t)
+	}
+}
+
+// typeSubscription is a handle of a subscriber, which may be cancelled while a callback for it is pending.
+// The elements of a slice of subscriptions are never overwritten, so that a snapshot of it may be used without a lock.
+type typeSubscription struct {
+	subscriber TypeSubscriber
+	cancelled  int32
+}
+
+func (s *typeSubscription) cancel() {
+	atomic.StoreInt32(&s.cancelled, 1)
+}
+
+func (s *typeSubscription) active() bool {
+	return atomic.LoadInt32(&s.cancelled) == 0
+}
+
+func (s *typeSubscription) onNamespaceCreated(namespace Namespace) {
+	if s.active() {
+		s.subscriber.OnNamespaceCreated(namespace)
 	}
+}
+
+func (s *typeSubscription) onTypeCreated(t *Type) {
+	if s.active() {
+		s.subscriber.OnTypeCreated(t)
+	}
+}
+
+func (s *typeSubscription) onNamespaceModified(namespace Namespace) {
+	if ms, ok := s.subscriber.(ModifierSubscriber); ok && s.active() {
+		ms.OnNamespaceModified(namespace)
+	}
+}
+
+func (s *typeSubscription) onTypeModified(t *Type) {
+	if ms, ok := s.subscriber.(ModifierSubscriber); ok && s.active() {
+		ms.OnTypeModified(t)
+	}
+}
+
+func (r *Registry) registerTrait(trait Trait) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checkTraitNameLocked(trait)
+	r.knownTraits = append(r.knownTraits, trait)
+}
+
+func (r *Registry) registerProperty(p Property) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.knownProperties = append(r.knownProperties, p)
+}
+
+func (r *Registry) subNamespaces(parent Namespace) []Namespace {
+	var result []Namespace
+	for _, namespace := range r.Namespaces() {
+		if namespace.parent != nil && namespace.parent.Key() == parent.Key() {
+			result = append(result, namespace)
+		}
+	}
+
+	return result
+}
+
+func (r *Registry) namespaceTypes(namespace Namespace) []*Type {
+	var result []*Type
+	for _, t := range r.Types() {
+		if t.parent == nil && t.namespace.Key() == namespace.Key() {
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
 
-	for _, t := range r.knownTypes {
-		s.OnTypeCreated(t)
+func (r *Registry) subtypes(parent *Type) []*Type {
+	var result []*Type
+	for _, t := range r.Types() {
+		if t.parent == parent {
+			result = append(result, t)
+		}
 	}
 
-	r.subscribers = append(r.subscribers, s)
+	return result
 }
@@ -1,11 +1,22 @@
 package errorx
 
 import (
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
+var (
+	registryTestNamespace      = NewNamespace("registry")
+	registryTestNamespaceChild = registryTestNamespace.NewSubNamespace("child")
+	registryTestType           = registryTestNamespace.NewType("type")
+	registryTestSubtype        = registryTestType.NewSubtype("subtype")
+	registryTestChildType      = registryTestNamespaceChild.NewType("type")
+)
+
 func TestRegistry(t *testing.T) {
 	s := &testSubscriber{}
 	RegisterTypeSubscriber(s)
@@ -20,6 +31,112 @@ func TestRegistry(t *testing.T) {
 	require.Contains(t, s.types, errorType)
 }
 
+func TestRegistryModifiers(t *testing.T) {
+	s := &testModifierSubscriber{}
+	RegisterTypeSubscriber(s)
+	defer UnregisterTypeSubscriber(s)
+
+	ns := NewNamespace("TestRegistryModifiers")
+	require.Empty(t, s.modifiedNamespaces)
+	ns = ns.ApplyModifiers(TypeModifierTransparent)
+	require.Len(t, s.modifiedNamespaces, 1)
+	require.Equal(t, ns.Key(), s.modifiedNamespaces[0].Key())
+	require.True(t, s.modifiedNamespaces[0].modifiers.Transparent())
+
+	errorType := ns.NewType("Test")
+	require.Empty(t, s.modifiedTypes)
+	errorType.ApplyModifiers(TypeModifierOmitStackTrace)
+	require.Equal(t, []*Type{errorType}, s.modifiedTypes)
+
+	lateSubscriber := &testModifierSubscriber{}
+	RegisterTypeSubscriber(lateSubscriber)
+	defer UnregisterTypeSubscriber(lateSubscriber)
+
+	var created Namespace
+	for _, namespace := range lateSubscriber.createdNamespaces {
+		if namespace.Key() == ns.Key() {
+			created = namespace
+		}
+	}
+	require.Equal(t, ns.Key(), created.Key())
+	require.True(t, created.modifiers.Transparent())
+	require.Empty(t, lateSubscriber.modifiedNamespaces)
+	require.Empty(t, lateSubscriber.modifiedTypes)
+
+	namespace, ok := LookupNamespace("TestRegistryModifiers")
+	require.True(t, ok)
+	require.True(t, namespace.modifiers.Transparent())
+}
+
+func TestRegistryUnsubscribe(t *testing.T) {
+	s := &testSubscriber{}
+	RegisterTypeSubscriber(s)
+
+	ns := NewNamespace("TestRegistryUnsubscribe")
+	require.Contains(t, s.namespaces, ns.Key())
+
+	UnregisterTypeSubscriber(s)
+	UnregisterTypeSubscriber(s)
+
+	errorType := ns.NewType("Test")
+	require.NotContains(t, s.types, errorType)
+}
+
+func TestRegistryConcurrency(t *testing.T) {
+	ns := NewNamespace("TestRegistryConcurrency")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				ns.NewType(strconv.Itoa(i*10 + j)).ApplyModifiers(TypeModifierOmitStackTrace)
+			}
+		}(i)
+
+		go func() {
+			defer wg.Done()
+			s := &testModifierSubscriber{}
+			RegisterTypeSubscriber(s)
+			_ = RegisteredTypes()
+			UnregisterTypeSubscriber(s)
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, ns.Types(), 100)
+}
+
+func TestRegistryReentrantSubscriber(t *testing.T) {
+	registry := NewRegistry()
+	s := &testReentrantSubscriber{registry: registry}
+	registry.RegisterTypeSubscriber(s)
+
+	errorType := registry.NewNamespace("reentrant").NewType("type")
+	derived, ok := registry.LookupType("reentrant.type_derived")
+	require.True(t, ok)
+	require.Equal(t, []*Type{errorType, derived}, registry.Types())
+	require.Equal(t, []*Type{errorType, derived}, s.found)
+}
+
+// testReentrantSubscriber declares a derived type for each type declared, and looks types up from a callback
+type testReentrantSubscriber struct {
+	registry *Registry
+	found    []*Type
+}
+
+func (s *testReentrantSubscriber) OnNamespaceCreated(namespace Namespace) {}
+
+func (s *testReentrantSubscriber) OnTypeCreated(t *Type) {
+	if found, ok := s.registry.LookupType(t.FullName()); ok {
+		s.found = append(s.found, found)
+	}
+	if !strings.HasSuffix(t.FullName(), "_derived") {
+		t.Namespace().NewType("type_derived")
+	}
+}
+
 type testSubscriber struct {
 	types      []*Type
 	namespaces []NamespaceKey
@@ -32,3 +149,146 @@ func (s *testSubscriber) OnNamespaceCreated(namespace Namespace) {
 func (s *testSubscriber) OnTypeCreated(t *Type) {
 	s.types = append(s.types, t)
 }
+
+func TestRegistryIntrospection(t *testing.T) {
+	t.Run("Namespaces", func(t *testing.T) {
+		namespaces := RegisteredNamespaces()
+		require.Contains(t, namespaces, CommonErrors)
+		require.Contains(t, namespaces, testNamespace)
+	})
+
+	t.Run("Types", func(t *testing.T) {
+		types := RegisteredTypes()
+		require.Contains(t, types, AssertionFailed)
+		require.Contains(t, types, testSubtype1)
+	})
+
+	t.Run("Traits", func(t *testing.T) {
+		traits := RegisteredTraits()
+		require.Contains(t, traits, Timeout())
+		require.Contains(t, traits, testTrait0)
+	})
+
+	t.Run("Properties", func(t *testing.T) {
+		properties := RegisteredProperties()
+		require.Contains(t, properties, PropertyPayload())
+		require.Contains(t, properties, PropertyContext())
+		require.NotContains(t, properties, propertyUnderlying)
+	})
+
+	t.Run("Lookup", func(t *testing.T) {
+		errorType, ok := LookupType("common.assertion_failed")
+		require.True(t, ok)
+		require.Equal(t, AssertionFailed, errorType)
+
+		_, ok = LookupType("common.no_such_type")
+		require.False(t, ok)
+
+		namespace, ok := LookupNamespace("traits2.child")
+		require.True(t, ok)
+		require.Equal(t, traitTestNamespace2Child.Key(), namespace.Key())
+
+		missing, ok := LookupNamespace("no_such_namespace")
+		require.False(t, ok)
+		require.Empty(t, missing.Types())
+		require.Empty(t, missing.SubNamespaces())
+		require.Empty(t, missing.Modifiers())
+		require.False(t, missing.HasModifier(TypeModifierTransparent))
+	})
+
+	t.Run("Tree", func(t *testing.T) {
+		subNamespaces := registryTestNamespace.SubNamespaces()
+		require.Len(t, subNamespaces, 1)
+		require.Equal(t, registryTestNamespaceChild.Key(), subNamespaces[0].Key())
+		require.Empty(t, registryTestNamespaceChild.SubNamespaces())
+
+		require.Equal(t, []*Type{registryTestType}, registryTestNamespace.Types())
+		require.Equal(t, []*Type{registryTestChildType}, registryTestNamespaceChild.Types())
+		require.Equal(t, []*Type{registryTestSubtype}, registryTestType.Subtypes())
+		require.Empty(t, registryTestSubtype.Subtypes())
+
+		types := CommonErrors.Types()
+		require.Contains(t, types, UnsupportedOperation)
+		require.NotContains(t, types, NotImplemented)
+		require.Contains(t, UnsupportedOperation.Subtypes(), NotImplemented)
+	})
+}
+
+type testModifierSubscriber struct {
+	testSubscriber
+	createdNamespaces  []Namespace
+	modifiedTypes      []*Type
+	modifiedNamespaces []Namespace
+}
+
+func (s *testModifierSubscriber) OnNamespaceCreated(namespace Namespace) {
+	s.testSubscriber.OnNamespaceCreated(namespace)
+	s.createdNamespaces = append(s.createdNamespaces, namespace)
+}
+
+func (s *testModifierSubscriber) OnNamespaceModified(namespace Namespace) {
+	s.modifiedNamespaces = append(s.modifiedNamespaces, namespace)
+}
+
+func (s *testModifierSubscriber) OnTypeModified(t *Type) {
+	s.modifiedTypes = append(s.modifiedTypes, t)
+}
+
+func TestIsolatedRegistry(t *testing.T) {
+	globalSubscriber := &testSubscriber{}
+	RegisterTypeSubscriber(globalSubscriber)
+	defer UnregisterTypeSubscriber(globalSubscriber)
+
+	// parallel subtests are grouped, so that the global subscriber outlives them
+	t.Run("Parallel", func(t *testing.T) {
+		for _, name := range []string{"First", "Second"} {
+			name := name
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				registry := NewRegistry()
+				s := &testSubscriber{}
+				registry.RegisterTypeSubscriber(s)
+				defer registry.UnregisterTypeSubscriber(s)
+
+				trait := registry.RegisterTrait("isolated")
+				property := registry.RegisterPrintableProperty("isolated", PropertyModifierSensitive)
+				ns := registry.NewNamespace("foo", trait)
+				child := ns.NewSubNamespace("child")
+				errorType := ns.NewType("bar")
+				subtype := errorType.NewSubtype("sub").ApplyModifiers(TypeModifierOmitStackTrace)
+				childType := child.NewType("bar")
+
+				require.Equal(t, []*Type{errorType, subtype, childType}, registry.Types())
+				require.Equal(t, []Trait{trait}, registry.Traits())
+				require.Equal(t, []Property{property}, registry.Properties())
+				require.True(t, property.Printable())
+				require.True(t, property.Sensitive())
+				require.Len(t, registry.Namespaces(), 2)
+				require.Equal(t, []*Type{errorType}, ns.Types())
+				require.Equal(t, []*Type{subtype}, errorType.Subtypes())
+				require.Equal(t, []NamespaceKey{ns.Key(), child.Key()}, s.namespaces)
+				require.Equal(t, []*Type{errorType, subtype, childType}, s.types)
+
+				found, ok := registry.LookupType("foo.bar")
+				require.True(t, ok)
+				require.Equal(t, errorType, found)
+
+				require.Empty(t, registry.FindDuplicateNames())
+				ns.NewType("bar")
+				require.Len(t, registry.FindDuplicateNames(), 1)
+
+				require.NotContains(t, RegisteredTypes(), errorType)
+				require.NotContains(t, RegisteredTraits(), trait)
+				require.NotContains(t, RegisteredProperties(), property)
+				require.NotContains(t, globalSubscriber.types, errorType)
+				require.NotContains(t, globalSubscriber.namespaces, ns.Key())
+
+				err := subtype.New("isolated")
+				require.True(t, err.IsOfType(errorType))
+				require.True(t, err.HasTrait(trait))
+				require.Equal(t, "foo.bar.sub: isolated", err.Error())
+			})
+		}
+	})
+}
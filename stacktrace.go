@@ -68,6 +68,15 @@ func collectStackTrace() *stackTrace {
 	}
 }
 
+// collectCallerStackTrace collects a stack trace starting with a caller of the function it is called from.
+func collectCallerStackTrace() *stackTrace {
+	var pc [stackTraceDepth]uintptr
+	depth := runtime.Callers(3, pc[:])
+	return &stackTrace{
+		pc: pc[:depth],
+	}
+}
+
 type stackTrace struct {
 	pc              []uintptr
 	causeStackTrace *stackTrace
@@ -19,6 +19,7 @@ var (
 // TypeSwitch is used to perform a switch around the type of an error.
 // For nil errors, returns nil.
 // For error types not in the 'types' list, including non-errorx errors, NotRecognisedType() is returned.
+// Non-errorx errors are checked against the classifiers, see RegisterTypeClassifier.
 // It is safe to treat NotRecognisedType() as 'any other type of not-nil error' case.
 // The effect is equivalent to a series of IsOfType() checks.
 //
@@ -30,6 +31,14 @@ func TypeSwitch(err error, types ...*Type) *Type {
 	case err == nil:
 		return nil
 	case typed == nil:
+		if classified := classifyType(err); classified != nil {
+			for _, t := range types {
+				if classified.IsOfType(t) {
+					return t
+				}
+			}
+		}
+
 		return NotRecognisedType()
 	default:
 		for _, t := range types {
@@ -44,22 +53,19 @@ func TypeSwitch(err error, types ...*Type) *Type {
 
 // TraitSwitch is used to perform a switch around the trait of an error.
 // For nil errors, returns CaseNoError().
-// For error types that lack any of the provided traits, including non-errorx errors, CaseNoTrait() is returned.
+// For error types that lack any of the provided traits, CaseNoTrait() is returned.
+// Non-errorx errors are checked against the classifiers, see RegisterTraitClassifier.
 // It is safe to treat CaseNoTrait() as 'any other kind of not-nil error' case.
 // The effect is equivalent to a series of HasTrait() checks.
 //
 // NB: if more than one provided types matches the error, the first match in the providers list is recognised.
 func TraitSwitch(err error, traits ...Trait) Trait {
-	typed := Cast(err)
-
 	switch {
 	case err == nil:
 		return CaseNoError()
-	case typed == nil:
-		return CaseNoTrait()
 	default:
 		for _, t := range traits {
-			if typed.HasTrait(t) {
+			if HasTrait(err, t) {
 				return t
 			}
 		}
@@ -0,0 +1,164 @@
+package errorx
+
+import (
+	"fmt"
+)
+
+// MessageTemplate is an error message with named placeholders bound to properties, such as 'user {user_id} not found'.
+// Unlike a formatted message, an error created from a template keeps both the template and the values of its placeholders,
+// so that the errors with the same template may be grouped together regardless of the values, see Error.MessageTemplate.
+// Use '{{' and '}}' for literal braces.
+type MessageTemplate struct {
+	text         string
+	properties   []Property
+	placeholders []Property
+}
+
+// NewMessageTemplate creates a template with placeholders bound to properties by label.
+// Panics if a placeholder has no property with the same label, or if a label is shared by several properties.
+// A template is expected to be created once, typically alongside the error types.
+func NewMessageTemplate(text string, properties ...Property) *MessageTemplate {
+	labels := make(map[string]Property, len(properties))
+	for _, p := range properties {
+		if _, ok := labels[p.label]; ok {
+			panic(fmt.Sprintf("message template %q: property %s is bound more than once", text, p.label))
+		}
+		labels[p.label] = p
+	}
+
+	var placeholders []Property
+	expandTemplate(text, func(label string) (interface{}, bool) {
+		p, ok := labels[label]
+		if !ok {
+			panic(fmt.Sprintf("message template %q: no property is bound to placeholder {%s}", text, label))
+		}
+		placeholders = append(placeholders, p)
+		return nil, false
+	})
+
+	return &MessageTemplate{
+		text:         text,
+		properties:   properties,
+		placeholders: placeholders,
+	}
+}
+
+// Text returns a raw text of this template, with placeholders intact.
+func (t *MessageTemplate) Text() string {
+	return t.text
+}
+
+// Properties returns the properties bound to this template, in the order their values are expected, see Type.NewFromTemplate.
+func (t *MessageTemplate) Properties() []Property {
+	return append([]Property(nil), t.properties...)
+}
+
+// String implements fmt.Stringer.
+func (t *MessageTemplate) String() string {
+	return t.text
+}
+
+// NewFromTemplate creates an error of this type with a message rendered from a template.
+// Values are bound to the properties of a template in order, and each is set as a property of an error.
+// A placeholder without a value is omitted from the message, and extra values are ignored.
+// A message is rendered each time it is output, so that the values of sensitive properties are redacted in it
+// just as in the rest of the output, see PropertyModifierSensitive and RevealSensitiveProperties.
+func (t *Type) NewFromTemplate(template *MessageTemplate, values ...interface{}) *Error {
+	return NewErrorBuilder(t).
+		WithMessageTemplate(template, values...).
+		Create()
+}
+
+// WrapFromTemplate creates an error of this type with another as original cause and with a message rendered from a template.
+// See Type.Wrap and Type.NewFromTemplate for details.
+func (t *Type) WrapFromTemplate(err error, template *MessageTemplate, values ...interface{}) *Error {
+	return NewErrorBuilder(t).
+		WithMessageTemplate(template, values...).
+		WithCause(err).
+		Create()
+}
+
+// WithMessageTemplate provides a message for an error rendered from a template, see Type.NewFromTemplate.
+// It replaces a message provided otherwise, if any.
+func (eb ErrorBuilder) WithMessageTemplate(template *MessageTemplate, values ...interface{}) ErrorBuilder {
+	if len(values) > len(template.properties) {
+		values = values[:len(template.properties)]
+	}
+
+	eb.template = template
+	eb.templateValues = values
+	eb.message = ""
+	return eb
+}
+
+// MessageTemplate returns a template this error was created from, if any.
+// Only the error itself is checked, not the wrapped causes.
+func (e *Error) MessageTemplate() (*MessageTemplate, bool) {
+	message, ok := e.templateMessage()
+	if !ok {
+		return nil, false
+	}
+
+	return message.template, true
+}
+
+// ExtractMessageTemplate returns a template of an error, if it is an errorx error created from one, see Error.MessageTemplate.
+func ExtractMessageTemplate(err error) (*MessageTemplate, bool) {
+	typedErr := Cast(err)
+	if typedErr == nil {
+		return nil, false
+	}
+
+	return typedErr.MessageTemplate()
+}
+
+// templateMessage is a message of an error created from a template, which is rendered each time it is output
+type templateMessage struct {
+	template *MessageTemplate
+	values   []interface{}
+}
+
+// render expands a template with the values of its placeholders, sensitive ones redacted as currently set up
+func (m *templateMessage) render() string {
+	return expandTemplate(m.template.text, func(label string) (interface{}, bool) {
+		for i, value := range m.values {
+			if p := m.template.properties[i]; p.label == label {
+				return redactedValue(p, value), true
+			}
+		}
+		return nil, false
+	})
+}
+
+// withTemplate sets the template and the values of its placeholders as properties of an error being created
+func (e *Error) withTemplate(template *MessageTemplate, values []interface{}) *Error {
+	for i, value := range values {
+		e = e.WithProperty(template.properties[i], value)
+	}
+
+	return e.WithProperty(propertyMessageTemplate, &templateMessage{template: template, values: values})
+}
+
+func (e *Error) templateMessage() (*templateMessage, bool) {
+	message, ok := e.properties.get(propertyMessageTemplate)
+	if !ok {
+		return nil, false
+	}
+
+	return message.(*templateMessage), true
+}
+
+// isBoundToTemplate checks if a property is rendered in a message of an error created from a template
+func (e *Error) isBoundToTemplate(p Property) bool {
+	template, ok := e.MessageTemplate()
+	if !ok {
+		return false
+	}
+
+	for _, bound := range template.placeholders {
+		if bound == p {
+			return true
+		}
+	}
+	return false
+}
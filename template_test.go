@@ -0,0 +1,137 @@
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	templateTestNamespace = NewNamespace("template")
+	templateTestType      = templateTestNamespace.NewType("type")
+	templateTestUser      = RegisterPrintableProperty("template_user")
+	templateTestRegion    = RegisterProperty("template_region")
+	templateTestSecret    = RegisterPrintableProperty("template_secret", PropertyModifierSensitive)
+	templateTestExtra     = RegisterPrintableProperty("template_extra")
+	templateTestMessage   = NewMessageTemplate("user {template_user} not found in {template_region}", templateTestUser, templateTestRegion)
+)
+
+func TestMessageTemplate(t *testing.T) {
+	t.Run("Render", func(t *testing.T) {
+		err := templateTestType.NewFromTemplate(templateTestMessage, 42, "eu")
+		require.Equal(t, "template.type: user 42 not found in eu", err.Error())
+		require.Equal(t, "user 42 not found in eu", err.Message())
+
+		user, ok := err.Property(templateTestUser)
+		require.True(t, ok)
+		require.Equal(t, 42, user)
+		region, ok := err.Property(templateTestRegion)
+		require.True(t, ok)
+		require.Equal(t, "eu", region)
+	})
+
+	t.Run("Template", func(t *testing.T) {
+		err := templateTestType.NewFromTemplate(templateTestMessage, 42, "eu")
+		template, ok := err.MessageTemplate()
+		require.True(t, ok)
+		require.Equal(t, templateTestMessage, template)
+		require.Equal(t, "user {template_user} not found in {template_region}", template.Text())
+		require.Equal(t, []Property{templateTestUser, templateTestRegion}, template.Properties())
+
+		other, ok := ExtractMessageTemplate(templateTestType.NewFromTemplate(templateTestMessage, 7, "us"))
+		require.True(t, ok)
+		require.Equal(t, template.Text(), other.Text())
+
+		_, ok = templateTestType.New("user 42 not found").MessageTemplate()
+		require.False(t, ok)
+		_, ok = ExtractMessageTemplate(errors.New("plain"))
+		require.False(t, ok)
+		_, ok = ExtractMessageTemplate(Decorate(err, "decorated"))
+		require.False(t, ok)
+	})
+
+	t.Run("OtherProperties", func(t *testing.T) {
+		err := templateTestType.NewFromTemplate(templateTestMessage, 42, "eu").WithProperty(templateTestExtra, "x")
+		require.Equal(t, "template.type: user 42 not found in eu {template_extra: x}", err.Error())
+
+		template := NewMessageTemplate("user {template_user} not found", templateTestUser, templateTestExtra)
+		err = templateTestType.NewFromTemplate(template, 42, "x")
+		require.Equal(t, "template.type: user 42 not found {template_extra: x}", err.Error())
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		err := templateTestType.WrapFromTemplate(errors.New("boom"), templateTestMessage, 42, "eu")
+		require.Equal(t, "template.type: user 42 not found in eu, cause: boom", err.Error())
+		_, ok := err.MessageTemplate()
+		require.True(t, ok)
+	})
+
+	t.Run("ValueCount", func(t *testing.T) {
+		err := templateTestType.NewFromTemplate(templateTestMessage, 42)
+		require.Equal(t, "template.type: user 42 not found in ", err.Error())
+		_, ok := err.Property(templateTestRegion)
+		require.False(t, ok)
+
+		err = templateTestType.NewFromTemplate(templateTestMessage, 42, "eu", "extra")
+		require.Equal(t, "template.type: user 42 not found in eu", err.Error())
+	})
+
+	t.Run("Sensitive", func(t *testing.T) {
+		template := NewMessageTemplate("bad token {template_secret}", templateTestSecret)
+		err := templateTestType.NewFromTemplate(template, "s3cr3t")
+		require.Equal(t, "template.type: bad token [redacted]", err.Error())
+
+		revealed := RevealSensitiveProperties(true)
+		require.Equal(t, "bad token s3cr3t", err.Message())
+		RevealSensitiveProperties(revealed)
+		require.Equal(t, "bad token [redacted]", err.Message())
+
+		secret, ok := err.Property(templateTestSecret)
+		require.True(t, ok)
+		require.Equal(t, "s3cr3t", secret)
+	})
+
+	t.Run("Braces", func(t *testing.T) {
+		template := NewMessageTemplate("{{literal}} {template_user}", templateTestUser)
+		require.Equal(t, "template.type: {literal} 1", templateTestType.NewFromTemplate(template, 1).Error())
+	})
+
+	t.Run("Builder", func(t *testing.T) {
+		err := NewErrorBuilder(templateTestType).
+			WithMessageTemplate(templateTestMessage, 42, "eu").
+			WithCause(errors.New("boom")).
+			Transparent().
+			Create()
+		require.Equal(t, "user 42 not found in eu, cause: boom", err.Error())
+		_, ok := err.MessageTemplate()
+		require.True(t, ok)
+	})
+
+	t.Run("Localize", func(t *testing.T) {
+		localizer := NewLocalizer()
+		localizer.RegisterBundle(NewMessageBundle("en", map[string]string{
+			templateTestType.FullName(): "No user {template_user}",
+		}))
+		err := templateTestType.NewFromTemplate(templateTestMessage, 42, "eu")
+		require.Equal(t, "No user 42", localizer.Localize(err, "en"))
+	})
+
+	t.Run("StackTrace", func(t *testing.T) {
+		err := templateTestType.NewFromTemplate(templateTestMessage, 42, "eu")
+		require.Contains(t, fmt.Sprintf("%+v", err), "TestMessageTemplate")
+	})
+}
+
+func TestMessageTemplateDeclaration(t *testing.T) {
+	require.Panics(t, func() {
+		NewMessageTemplate("user {template_user} in {template_region}", templateTestUser)
+	})
+	require.Panics(t, func() {
+		NewMessageTemplate("user {template_user}", templateTestUser, templateTestUser)
+	})
+	require.NotPanics(t, func() {
+		NewMessageTemplate("no placeholders", templateTestUser)
+	})
+}
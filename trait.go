@@ -1,26 +1,62 @@
 package errorx
 
 // Trait is a static characteristic of an error type.
-// All errors of a specific type possess exactly the same traits.
+// All errors of a specific type possess the same traits, save for those added to an error instance, see Error.WithTrait.
 // Traits are both defined along with an error and inherited from a supertype and a namespace.
+// A trait may also imply other traits, see Implies.
 type Trait struct {
-	id    uint64
-	label string
+	id      uint64
+	label   string
+	implied *impliedTraits
+}
+
+// TraitOption is an optional characteristic of a trait, see RegisterTrait.
+type TraitOption struct {
+	implied []Trait
+}
+
+// Implies is a trait option; an error which possesses a trait also possesses all the traits it implies, transitively.
+// For example, with RegisterTrait("timeout", Implies(Temporary())), each error type with such trait is temporary as well.
+// Only the traits already registered may be implied, so a trait can never imply itself, even through other traits.
+// A zero Trait{}, such as a package-level trait referenced before its initialization, makes RegisterTrait panic.
+func Implies(traits ...Trait) TraitOption {
+	return TraitOption{implied: traits}
 }
 
 // RegisterTrait declares a new distinct traits.
 // Traits are matched exactly, distinct traits are considered separate event if they have the same label.
-func RegisterTrait(label string) Trait {
-	return newTrait(label)
+//
+// A trait may only imply the traits registered before it, so that implications never form a cycle.
+// An attempt to imply a trait not yet registered, such as one declared later in an initialization cycle, panics.
+func RegisterTrait(label string, options ...TraitOption) Trait {
+	return globalRegistry.RegisterTrait(label, options...)
+}
+
+// Label returns a label a trait was registered with.
+func (t Trait) Label() string {
+	return t.label
+}
+
+// Implied returns all the traits implied by this one, transitively, see Implies.
+func (t Trait) Implied() []Trait {
+	if t.implied == nil {
+		return nil
+	}
+	return append([]Trait(nil), t.implied.traits...)
+}
+
+func (t Trait) String() string {
+	return t.label
 }
 
 // HasTrait checks if an error possesses the expected trait.
-// Traits are always properties of a type rather than of an instance, so trait check is an alternative to a type check.
+// Traits are mostly properties of a type rather than of an instance, so trait check is an alternative to a type check.
 // This alternative is preferable, though, as it is less brittle and generally creates less of a dependency.
+// Non-errorx errors are checked against the classifiers, see RegisterTraitClassifier.
 func HasTrait(err error, key Trait) bool {
 	typedErr := Cast(err)
 	if typedErr == nil {
-		return false
+		return err != nil && classifyTrait(err, key)
 	}
 
 	return typedErr.HasTrait(key)
@@ -38,6 +74,9 @@ func NotFound() Trait { return traitNotFound }
 // Duplicate is a trait that marks such an error where an update is failed as a duplicate.
 func Duplicate() Trait { return traitDuplicate }
 
+// RuntimeFault is a trait that marks an error recovered from a panic raised by runtime rather than by user code.
+func RuntimeFault() Trait { return traitRuntimeFault }
+
 // IsTemporary checks for Temporary trait.
 func IsTemporary(err error) bool {
 	return HasTrait(err, Temporary())
@@ -58,16 +97,68 @@ func IsDuplicate(err error) bool {
 	return HasTrait(err, Duplicate())
 }
 
+// IsRuntimeFault checks for RuntimeFault trait.
+func IsRuntimeFault(err error) bool {
+	return HasTrait(err, RuntimeFault())
+}
+
 var (
 	traitTemporary = RegisterTrait("temporary")
 	traitTimeout   = RegisterTrait("timeout")
 	traitNotFound  = RegisterTrait("not_found")
 	traitDuplicate = RegisterTrait("duplicate")
+
+	traitRuntimeFault = RegisterTrait("runtime_fault")
 )
 
-func newTrait(label string) Trait {
-	return Trait{
+func newTrait(registry *Registry, label string, options ...TraitOption) Trait {
+	trait := Trait{
 		id:    nextInternalID(),
 		label: label,
 	}
+
+	var implied []Trait
+	for _, option := range options {
+		for _, other := range option.implied {
+			if other.id == 0 {
+				panic("trait " + label + " implies a trait which is not registered yet, probably due to an initialization cycle")
+			}
+			implied = appendTrait(implied, other)
+			for _, transitive := range other.Implied() {
+				implied = appendTrait(implied, transitive)
+			}
+		}
+	}
+	if len(implied) > 0 {
+		trait.implied = &impliedTraits{traits: implied}
+	}
+
+	registry.registerTrait(trait)
+	return trait
+}
+
+// impliedTraits is a transitive closure of the implications of a trait
+type impliedTraits struct {
+	traits []Trait
+}
+
+// implies checks if a trait implies another one, see Implies
+func (t Trait) implies(other Trait) bool {
+	return t.implied != nil && containsTrait(t.implied.traits, other)
+}
+
+func appendTrait(traits []Trait, trait Trait) []Trait {
+	if containsTrait(traits, trait) {
+		return traits
+	}
+	return append(traits, trait)
+}
+
+func containsTrait(traits []Trait, trait Trait) bool {
+	for _, existing := range traits {
+		if existing == trait {
+			return true
+		}
+	}
+	return false
 }
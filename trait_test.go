@@ -1,6 +1,7 @@
 package errorx
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -83,3 +84,99 @@ func TestTraitNamespace(t *testing.T) {
 		require.True(t, HasTrait(err, testTrait2))
 	})
 }
+
+var (
+	implicationTestRegistry  = NewRegistry()
+	implicationTestRetryable = implicationTestRegistry.RegisterTrait("retryable", Implies(Temporary()))
+	implicationTestThrottled = implicationTestRegistry.RegisterTrait("throttled", Implies(implicationTestRetryable, testTrait0))
+	implicationTestNamespace = implicationTestRegistry.NewNamespace("implication", implicationTestRetryable)
+	implicationTestType      = implicationTestNamespace.NewType("type")
+	implicationTestThrottle  = implicationTestRegistry.NewNamespace("traits").NewType("throttle", implicationTestThrottled)
+)
+
+func TestTraitImplication(t *testing.T) {
+	t.Run("Implied", func(t *testing.T) {
+		require.Equal(t, []Trait{Temporary()}, implicationTestRetryable.Implied())
+		require.Equal(t, []Trait{implicationTestRetryable, Temporary(), testTrait0}, implicationTestThrottled.Implied())
+		require.Empty(t, Temporary().Implied())
+	})
+
+	t.Run("Transitive", func(t *testing.T) {
+		err := implicationTestThrottle.New("test")
+		require.True(t, err.HasTrait(implicationTestThrottled))
+		require.True(t, err.HasTrait(implicationTestRetryable))
+		require.True(t, IsTemporary(err))
+		require.True(t, HasTrait(Decorate(err, "decorated"), testTrait0))
+		require.False(t, IsTimeout(err))
+	})
+
+	t.Run("Namespace", func(t *testing.T) {
+		require.True(t, implicationTestType.HasTrait(Temporary()))
+		require.True(t, IsTemporary(implicationTestType.New("test")))
+	})
+
+	t.Run("TraitSwitch", func(t *testing.T) {
+		err := implicationTestThrottle.New("test")
+		require.Equal(t, Temporary(), TraitSwitch(err, Timeout(), Temporary()))
+	})
+
+	t.Run("Classified", func(t *testing.T) {
+		type throttledError struct{ error }
+
+		foreign := throttledError{errors.New("foreign")}
+		RegisterTraitClassifier(implicationTestThrottled, func(err error) bool {
+			_, ok := err.(throttledError)
+			return ok
+		})
+		require.True(t, IsTemporary(foreign))
+		require.True(t, HasTrait(foreign, implicationTestRetryable))
+	})
+
+	t.Run("Uninitialized", func(t *testing.T) {
+		require.Panics(t, func() {
+			implicationTestRegistry.RegisterTrait("broken", Implies(Trait{}))
+		})
+	})
+}
+
+func TestInstanceTrait(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		err := traitTestError.New("test").WithTrait(Temporary())
+		require.True(t, IsTemporary(err))
+		require.True(t, err.HasTrait(testTrait1))
+		require.False(t, IsTemporary(traitTestError.New("test")))
+		require.False(t, traitTestError.HasTrait(Temporary()))
+		require.True(t, err.IsOfType(traitTestError))
+		require.Equal(t, "traits.simple: test", err.Error())
+		require.Empty(t, err.Properties())
+	})
+
+	t.Run("Accumulated", func(t *testing.T) {
+		err := traitTestError.New("test").WithTrait(Temporary()).WithProperty(PropertyPayload(), 1).WithTrait(Timeout(), Temporary())
+		require.True(t, IsTemporary(err))
+		require.True(t, IsTimeout(err))
+		require.Equal(t, Timeout(), TraitSwitch(err, Timeout(), Temporary()))
+	})
+
+	t.Run("Implied", func(t *testing.T) {
+		err := traitTestError.New("test").WithTrait(implicationTestRetryable)
+		require.True(t, err.HasTrait(implicationTestRetryable))
+		require.True(t, IsTemporary(err))
+	})
+
+	t.Run("Transparent", func(t *testing.T) {
+		err := Decorate(traitTestError.New("test").WithTrait(Temporary()), "decorated")
+		require.True(t, IsTemporary(err))
+
+		err = Decorate(traitTestError.New("test"), "decorated").WithTrait(Timeout())
+		require.True(t, IsTimeout(err))
+		require.True(t, err.HasTrait(testTrait1))
+	})
+
+	t.Run("Opaque", func(t *testing.T) {
+		err := traitTestError2.Wrap(traitTestError.New("test").WithTrait(Temporary()), "wrapped")
+		require.False(t, IsTemporary(err))
+		require.True(t, IsTemporary(Cast(err.Cause())))
+		require.Equal(t, CaseNoTrait(), TraitSwitch(err, Temporary(), Timeout()))
+	})
+}
@@ -9,12 +9,14 @@ import (
 // May contain or inherit modifiers that alter the default properties for any error of this type.
 // May contain or inherit traits that all errors of this type will possess.
 type Type struct {
-	namespace Namespace
-	parent    *Type
-	id        uint64
-	fullName  string
-	traits    map[Trait]bool
-	modifiers modifiers
+	namespace     Namespace
+	parent        *Type
+	id            uint64
+	fullName      string
+	code          string
+	publicMessage string
+	traits        map[Trait]bool
+	modifiers     modifiers
 }
 
 var _ encoding.TextMarshaler = (*Type)(nil)
@@ -30,11 +32,23 @@ func (t *Type) NewSubtype(name string, traits ...Trait) *Type {
 }
 
 // ApplyModifiers makes a one-time modification of defaults in error creation.
+// Modifiers are not synchronized with error creation, so they must be applied along with a type declaration,
+// such as in a package-level var block, before any error of this type is created.
 func (t *Type) ApplyModifiers(modifiers ...TypeModifier) *Type {
-	t.modifiers = t.modifiers.ReplaceWith(newTypeModifiers(modifiers...))
+	t.namespace.registry().modifyType(t, t.modifiers.ReplaceWith(newTypeModifiers(modifiers...)))
 	return t
 }
 
+// Modifiers returns the modifiers in effect for this type, both applied to it and inherited from a supertype or a namespace.
+func (t *Type) Modifiers() []TypeModifier {
+	return effectiveModifiers(t.modifiers)
+}
+
+// HasModifier checks if a modifier is in effect for this type, see Modifiers.
+func (t *Type) HasModifier(modifier TypeModifier) bool {
+	return t.modifiers.Has(modifier)
+}
+
 // New creates an error of this type with a message.
 // Without args, leaves the original message intact, so a message may be generated or provided externally.
 // With args, a formatting is performed, and it is therefore expected a format string to be constant.
@@ -53,7 +67,7 @@ func (t *Type) NewWithNoMessage() *Error {
 
 // Wrap creates an error of this type with another as original cause.
 // As far as type checks are concerned, this error is the only one visible, with original present only in error message.
-// The original error will not pass its dynamic properties, and those are accessible only via direct walk over Cause() chain.
+// The original error will not pass its dynamic properties, except for sticky ones, and those are accessible only via direct walk over Cause() chain.
 // Without args, leaves the original message intact, so a message may be generated or provided externally.
 // With args, a formatting is performed, and it is therefore expected a format string to be constant.
 // NB: Wrap is NOT the reverse of errors.Unwrap() or Error.Unwrap() method; name may be changed in future releases to avoid confusion.
@@ -89,7 +103,7 @@ func (t *Type) IsOfType(other *Type) bool {
 	return false
 }
 
-// HasTrait checks if a type possesses the expected trait.
+// HasTrait checks if a type possesses the expected trait, either directly or as implied by another trait, see Implies.
 func (t *Type) HasTrait(key Trait) bool {
 	_, ok := t.traits[key]
 	return ok
@@ -99,6 +113,7 @@ func (t *Type) HasTrait(key Trait) bool {
 // Returns true either if both are of exactly the same type, or if the same is true for one of current type's ancestors.
 // Go 1.12 and below: for an error that does not have an errorx type, returns false.
 // Go 1.13 and above: for an error that does not have an errorx type, returns false unless it wraps another error of errorx type.
+// In either case, an error that does not have an errorx type is checked against the classifiers, see RegisterTypeClassifier.
 func IsOfType(err error, t *Type) bool {
 	return isOfType(err, t)
 }
@@ -108,6 +123,11 @@ func (t *Type) Supertype() *Type {
 	return t.parent
 }
 
+// Subtypes returns the immediate subtypes of this type, in order of registration.
+func (t *Type) Subtypes() []*Type {
+	return t.namespace.registry().subtypes(t)
+}
+
 // FullName returns a fully qualified name if type, is not presumed to be unique, see TypeSubscriber.
 func (t *Type) FullName() string {
 	return t.fullName
@@ -152,12 +172,19 @@ func newType(namespace Namespace, parent *Type, name string, traits ...Trait) *T
 			}
 		}
 
-		for trait := range namespace.collectTraits() {
+		add := func(trait Trait) {
 			result[trait] = true
+			for _, implied := range trait.Implied() {
+				result[implied] = true
+			}
+		}
+
+		for trait := range namespace.collectTraits() {
+			add(trait)
 		}
 
 		for _, trait := range traits {
-			result[trait] = true
+			add(trait)
 		}
 
 		return result
@@ -179,6 +206,6 @@ func newType(namespace Namespace, parent *Type, name string, traits ...Trait) *T
 		modifiers: collectModifiers(),
 	}
 
-	globalRegistry.registerType(t)
+	namespace.registry().registerType(t)
 	return t
 }
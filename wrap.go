@@ -25,6 +25,25 @@ func Decorate(err error, message string, args ...interface{}) *Error {
 		Create()
 }
 
+// MaskTraits performs a transparent wrap which hides some traits of the original error, leaving its type and properties intact.
+// HasTrait and TraitSwitch report none of the masked traits for the resulting error, be those traits of the error type,
+// added to an error instance or classified. For example, a temporary failure of a write which may already have happened
+// is not to be retried by an upper layer:
+//
+//	return errorx.MaskTraits(err, errorx.Temporary())
+//
+// A trait which implies a masked one, see Implies, is masked as well, lest an error be retryable but not temporary.
+// A trait may be added back by a wrapper above, see Error.WithTrait.
+func MaskTraits(err error, traits ...Trait) *Error {
+	errorCopy := NewErrorBuilder(transparentWrapper).
+		WithConditionallyFormattedMessage("").
+		WithCause(err).
+		Create().
+		WithProperty(propertyMaskedTraits, append([]Trait(nil), traits...))
+	errorCopy.hasTraits = true
+	return errorCopy
+}
+
 // EnhanceStackTrace has all the properties of the Decorate() method
 // and additionally extends the stack trace of the original error.
 // Designed to be used when a original error is passed from another goroutine rather than from a direct method call.
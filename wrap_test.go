@@ -3,6 +3,7 @@ package errorx
 import (
 	"errors"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -66,3 +67,60 @@ func TestDecorateMany(t *testing.T) {
 		require.NotEqual(t, testTypeBar2, err.(*Error).Type())
 	})
 }
+
+func TestMaskTraits(t *testing.T) {
+	t.Run("Type", func(t *testing.T) {
+		cause := traitTestTemporaryTimeoutError.New("test").WithProperty(PropertyPayload(), 1)
+		err := MaskTraits(cause, Temporary())
+		require.False(t, IsTemporary(err))
+		require.True(t, IsTimeout(err))
+		require.True(t, err.IsOfType(traitTestTemporaryTimeoutError))
+		require.Equal(t, traitTestTemporaryTimeoutError, err.Type())
+		require.Equal(t, cause.Error(), err.Error())
+		require.Equal(t, map[Property]interface{}{PropertyPayload(): 1}, err.Properties())
+		require.Equal(t, Timeout(), TraitSwitch(err, Temporary(), Timeout()))
+		require.True(t, IsTemporary(cause))
+	})
+
+	t.Run("Decorated", func(t *testing.T) {
+		err := Decorate(MaskTraits(traitTestTemporaryTimeoutError.New("test"), Temporary(), Timeout()), "decorated")
+		require.Equal(t, CaseNoTrait(), TraitSwitch(err, Temporary(), Timeout()))
+		require.True(t, IsOfType(err, traitTestTimeoutError))
+	})
+
+	t.Run("Instance", func(t *testing.T) {
+		err := MaskTraits(traitTestError.New("test").WithTrait(Temporary()), Temporary())
+		require.False(t, IsTemporary(err))
+		require.True(t, IsTemporary(err.WithTrait(Temporary())))
+		require.True(t, IsTemporary(Decorate(err, "decorated").WithTrait(Temporary())))
+	})
+
+	t.Run("Implied", func(t *testing.T) {
+		err := MaskTraits(implicationTestThrottle.New("test"), Temporary())
+		require.False(t, IsTemporary(err))
+		require.False(t, err.HasTrait(implicationTestRetryable))
+		require.False(t, HasTrait(err, implicationTestThrottled))
+		require.True(t, err.HasTrait(testTrait0))
+		require.Equal(t, CaseNoTrait(), TraitSwitch(err, implicationTestThrottled, implicationTestRetryable, Temporary()))
+
+		err = MaskTraits(implicationTestThrottle.New("test"), implicationTestRetryable)
+		require.False(t, err.HasTrait(implicationTestThrottled))
+		require.True(t, IsTemporary(err))
+
+		err = MaskTraits(traitTestError.New("test").WithTrait(implicationTestRetryable), Temporary())
+		require.False(t, err.HasTrait(implicationTestRetryable))
+	})
+
+	t.Run("Foreign", func(t *testing.T) {
+		err := MaskTraits(os.ErrNotExist, NotFound())
+		require.False(t, IsNotFound(err))
+		require.True(t, IsNotFound(Decorate(os.ErrNotExist, "decorated")))
+		require.Equal(t, os.ErrNotExist.Error(), err.Error())
+	})
+
+	t.Run("StackTrace", func(t *testing.T) {
+		output := fmt.Sprintf("%+v", MaskTraits(errors.New("boom"), Temporary()))
+		require.NotContains(t, output, "errorx.MaskTraits()", output)
+		require.Contains(t, output, "TestMaskTraits", output)
+	})
+}